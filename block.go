@@ -0,0 +1,50 @@
+package validator
+
+// stepBlock gates the steps added to a validator in a block
+type stepBlock[T any] struct {
+	owner     *validator[T]
+	condition func(T) bool
+}
+
+// WhenAll returns a block whose steps run only when all the predicates return
+// true for the validated value. The steps are added with Do:
+//
+//	v.WhenAll(isEU, isBusiness).Do(func() {
+//		RuleFor(v, "vat", func(o Order) string { return o.VAT }).NotEmpty()
+//	})
+func (v *validator[T]) WhenAll(predicates ...func(T) bool) *stepBlock[T] {
+	return &stepBlock[T]{owner: v, condition: func(src T) bool {
+		for _, predicate := range predicates {
+			if !predicate(src) {
+				return false
+			}
+		}
+		return true
+	}}
+}
+
+// WhenAny returns a block whose steps run only when any of the predicates
+// returns true for the validated value. The steps are added with Do
+func (v *validator[T]) WhenAny(predicates ...func(T) bool) *stepBlock[T] {
+	return &stepBlock[T]{owner: v, condition: func(src T) bool {
+		for _, predicate := range predicates {
+			if predicate(src) {
+				return true
+			}
+		}
+		return false
+	}}
+}
+
+// Do calls block, and gates every step it adds to the validator with the
+// predicates of the block. The predicates are checked before the ones of the
+// steps, and blocks can be nested
+func (b *stepBlock[T]) Do(block func()) *validator[T] {
+	first := len(b.owner.validators)
+	block()
+
+	for _, step := range b.owner.validators[first:] {
+		step.conditions = append([]func(T) bool{b.condition}, step.conditions...)
+	}
+	return b.owner
+}
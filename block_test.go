@@ -0,0 +1,115 @@
+package validator_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type invoice struct {
+	Country  string
+	Business bool
+	VAT      string
+	Total    int
+}
+
+func isEU(i invoice) bool       { return i.Country == "ES" || i.Country == "FR" }
+func isBusiness(i invoice) bool { return i.Business }
+
+func Test_When_WhenPredicateDoesNotHold_ShouldSkipTheStep(t *testing.T) {
+	tests := []struct {
+		name    string
+		invoice invoice
+		want    []string
+	}{
+		{name: "EU business", invoice: invoice{Country: "ES", Business: true}, want: []string{"vat: must not be empty"}},
+		{name: "EU consumer", invoice: invoice{Country: "ES"}, want: []string{}},
+		{name: "non EU business", invoice: invoice{Country: "US", Business: true}, want: []string{"total: must not be empty"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			v := validator.New[invoice]()
+			validator.RuleFor(v, "vat", func(i invoice) string { return i.VAT }).
+				When(isEU).
+				When(isBusiness).
+				NotEmpty()
+			validator.RuleFor(v, "total", func(i invoice) int { return i.Total }).
+				Unless(isEU).
+				NotEmpty()
+
+			// Act
+			result := v.Validate(tt.invoice)
+
+			// Assert
+			assert.Equal(t, tt.want, result.GetFailureMessages())
+		})
+	}
+}
+
+func Test_When_WhenStepIsCustom_ShouldGateIt(t *testing.T) {
+	// Arrange
+	v := validator.New[invoice]().Parallel(2)
+	v.AddStep(func(invoice) error { return errors.New("blocked country") }).
+		When(func(i invoice) bool { return i.Country == "XX" })
+	v.AddStep(func(invoice) error { return errors.New("not a business") }).
+		Unless(isBusiness)
+
+	// Act
+	result := v.Validate(invoice{Country: "XX", Business: true})
+
+	// Assert
+	assert.Equal(t, []string{"blocked country"}, result.GetFailureMessages())
+}
+
+func Test_WhenAll_WhenAllPredicatesHold_ShouldRunTheBlock(t *testing.T) {
+	tests := []struct {
+		name    string
+		invoice invoice
+		want    []string
+	}{
+		{name: "all hold", invoice: invoice{Country: "FR", Business: true}, want: []string{"vat: must not be empty", "missing VAT"}},
+		{name: "one holds", invoice: invoice{Country: "FR"}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			v := validator.New[invoice]()
+			v.WhenAll(isEU, isBusiness).Do(func() {
+				validator.RuleFor(v, "vat", func(i invoice) string { return i.VAT }).
+					NotEmpty()
+				v.AddStep(func(invoice) error { return errors.New("missing VAT") })
+			})
+
+			// Act
+			result := v.Validate(tt.invoice)
+
+			// Assert
+			assert.Equal(t, tt.want, result.GetFailureMessages())
+		})
+	}
+}
+
+func Test_WhenAny_WhenBlocksAreNested_ShouldCheckBothBlocks(t *testing.T) {
+	// Arrange
+	v := validator.New[invoice]()
+	v.AddStep(func(invoice) error { return errors.New("always") })
+	v.WhenAny(isEU, isBusiness).Do(func() {
+		v.AddStep(func(invoice) error { return errors.New("EU or business") })
+		v.WhenAll(isBusiness).Do(func() {
+			v.AddStep(func(invoice) error { return errors.New("EU or business, and business") })
+		})
+	})
+
+	// Act
+	consumer := v.Validate(invoice{Country: "ES"})
+	outside := v.Validate(invoice{Country: "US"})
+
+	// Assert
+	assert.Equal(t, []string{"always", "EU or business"}, consumer.GetFailureMessages())
+	assert.Equal(t, []string{"always"}, outside.GetFailureMessages())
+}
@@ -0,0 +1,412 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/types"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	validatorPath = "github.com/cgxarrie-go/validator"
+	rulesPath     = "github.com/cgxarrie-go/validator/rules"
+	tagsPath      = "github.com/cgxarrie-go/validator/tags"
+)
+
+type generator struct {
+	pkg       *types.Package
+	prefix    string
+	imports   map[string]string
+	structs   map[*types.Named]*structPlan
+	order     []*structPlan
+	ruleNames map[string]string
+	ruleExprs []string
+	funcNames map[string]bool
+	vars      map[string]int
+}
+
+// generate returns the source of the validators of the named struct types of
+// the package in dir
+func generate(dir string, typeNames []string) ([]byte, error) {
+	pkg, err := load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		pkg:       pkg,
+		prefix:    lowerFirst(typeNames[0]),
+		imports:   map[string]string{validatorPath: "validator"},
+		structs:   make(map[*types.Named]*structPlan),
+		ruleNames: make(map[string]string),
+		funcNames: make(map[string]bool),
+	}
+
+	roots := make([]*structPlan, 0, len(typeNames))
+	for _, name := range typeNames {
+		obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("type %s not found in package %s", name, pkg.Name())
+		}
+		named, ok := obj.Type().(*types.Named)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a named type", name)
+		}
+
+		plan, err := g.compileStruct(named)
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, plan)
+	}
+
+	return g.emit(roots)
+}
+
+func (g *generator) emit(roots []*structPlan) ([]byte, error) {
+	body := &bytes.Buffer{}
+
+	for _, plan := range roots {
+		name := plan.named.Obj().Name()
+		fmt.Fprintf(body, "// %[1]sValidator validates %[1]s values with the rules in their validate\n", name)
+		fmt.Fprintf(body, "// tags. It reports the same failures as the validator built by\n")
+		fmt.Fprintf(body, "// tags.FromTags, without reflection\n")
+		fmt.Fprintf(body, "type %sValidator struct{}\n\n", name)
+		fmt.Fprintf(body, "// Validate validates src\n")
+		fmt.Fprintf(body, "func (%[1]sValidator) Validate(src %[1]s) validator.Result {\n", name)
+		fmt.Fprintf(body, "return %s(src)\n}\n\n", g.structFunc(plan))
+		fmt.Fprintf(body, "var _ validator.Validator[%[1]s] = %[1]sValidator{}\n\n", name)
+	}
+
+	for _, plan := range g.order {
+		if len(plan.fields) == 0 && !contains(roots, plan) {
+			continue
+		}
+		if err := g.emitStruct(body, plan); err != nil {
+			return nil, err
+		}
+	}
+
+	out := &bytes.Buffer{}
+	fmt.Fprintf(out, "%s\n\npackage %s\n\n", header, g.pkg.Name())
+
+	// standard library imports go first, apart from the others
+	std, other := make([]string, 0), make([]string, 0)
+	for path := range g.imports {
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			other = append(other, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+	fmt.Fprintf(out, "import (\n")
+	for _, path := range std {
+		fmt.Fprintf(out, "%q\n", path)
+	}
+	if len(std) > 0 {
+		fmt.Fprintf(out, "\n")
+	}
+	for _, path := range other {
+		fmt.Fprintf(out, "%q\n", path)
+	}
+	fmt.Fprintf(out, ")\n\n")
+
+	if len(g.ruleExprs) > 0 {
+		fmt.Fprintf(out, "var (\n")
+		for _, expr := range g.ruleExprs {
+			fmt.Fprintf(out, "%s = %s\n", g.ruleNames[expr], expr)
+		}
+		fmt.Fprintf(out, ")\n\n")
+	}
+
+	out.Write(body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+func (g *generator) emitStruct(w *bytes.Buffer, plan *structPlan) error {
+	name := g.typeString(plan.named)
+
+	fmt.Fprintf(w, "func %s(src %s) validator.Result {\n", g.structFunc(plan), name)
+	if len(plan.fields) == 0 {
+		fmt.Fprintf(w, "return validator.Result{}\n}\n\n")
+		return nil
+	}
+
+	fmt.Fprintf(w, "result := validator.Result{}\n")
+	for _, field := range plan.fields {
+		field.funcName = g.funcName("validate" + plan.named.Obj().Name() + field.goName)
+		fmt.Fprintf(w, "if res := %s(src.%s); res.IsFailure() {\n", field.funcName, field.goName)
+		fmt.Fprintf(w, "result.AddFailureAt(%q, res)\n}\n", field.name)
+	}
+	fmt.Fprintf(w, "return result\n}\n\n")
+
+	for _, field := range plan.fields {
+		g.vars = make(map[string]int)
+		fmt.Fprintf(w, "func %s(v %s) validator.Result {\n", field.funcName, g.typeString(field.typ))
+		fmt.Fprintf(w, "res := validator.Result{}\n")
+		if err := g.emitValue(w, field.value, field.typ, "v", "res"); err != nil {
+			return fmt.Errorf("%s.%s: %w", name, field.goName, err)
+		}
+		fmt.Fprintf(w, "return res\n}\n\n")
+	}
+
+	return nil
+}
+
+// emitValue writes the statements validating the value in v of type t with
+// plan, and adding the failures to res
+func (g *generator) emitValue(w *bytes.Buffer, plan *valuePlan, t types.Type, v, res string) error {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		if plan.required {
+			g.use(tagsPath)
+			fmt.Fprintf(w, "if %s == nil {\n%s.AddFailure(tags.Required())\n} else {\n", v, res)
+		} else {
+			fmt.Fprintf(w, "if %s != nil {\n", v)
+		}
+
+		elem := g.newVar("v")
+		fmt.Fprintf(w, "%s := *%s\n", elem, v)
+		if err := g.emitValue(w, plan, ptr.Elem(), elem, res); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "}\n")
+		return nil
+	}
+
+	if !plan.required && !plan.omitEmpty {
+		return g.emitChecks(w, plan, t, v, res)
+	}
+
+	checks := &bytes.Buffer{}
+	if err := g.emitChecks(checks, plan, t, v, res); err != nil {
+		return err
+	}
+
+	if plan.required {
+		empty, err := g.zeroExpr(v, t, true, true)
+		if err != nil {
+			return err
+		}
+		g.use(tagsPath)
+		fmt.Fprintf(w, "if %s {\n%s.AddFailure(tags.Required())\n", empty, res)
+		if checks.Len() > 0 {
+			fmt.Fprintf(w, "} else {\n")
+		}
+	} else {
+		notEmpty, err := g.zeroExpr(v, t, false, true)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "if %s {\n", notEmpty)
+	}
+
+	w.Write(checks.Bytes())
+	fmt.Fprintf(w, "}\n")
+	return nil
+}
+
+// ordered reports whether the keys of type t are sorted by their value, as
+// integers, floats and strings are, and not by their text
+func ordered(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&(types.IsInteger|types.IsFloat|types.IsString) != 0
+}
+
+// emitChecks writes the statements checking the rules, the nested struct and
+// the elements of the value in v
+func (g *generator) emitChecks(w *bytes.Buffer, plan *valuePlan, t types.Type, v, res string) error {
+	for _, rule := range plan.rules {
+		w.WriteString(rule(v, res))
+	}
+
+	if plan.nested != nil {
+		fmt.Fprintf(w, "%s.Merge(%s(%s))\n", res, g.structFunc(plan.nested), v)
+	}
+
+	if plan.dive == nil {
+		return nil
+	}
+
+	elem := g.newVar("e")
+	elemRes := g.newVar("res")
+
+	switch u := t.Underlying().(type) {
+	case *types.Map:
+		g.use("fmt")
+		g.use("sort")
+		keys, key := g.newVar("keys"), g.newVar("k")
+		fmt.Fprintf(w, "%s := make([]%s, 0, len(%s))\n", keys, g.typeString(u.Key()), v)
+		fmt.Fprintf(w, "for %s := range %s {\n%s = append(%s, %s)\n}\n", key, v, keys, keys, key)
+		if ordered(u.Key()) {
+			fmt.Fprintf(w, "sort.Slice(%[1]s, func(i, j int) bool {\nreturn %[1]s[i] < %[1]s[j]\n})\n", keys)
+		} else {
+			fmt.Fprintf(w, "sort.Slice(%[1]s, func(i, j int) bool {\nreturn fmt.Sprint(%[1]s[i]) < fmt.Sprint(%[1]s[j])\n})\n", keys)
+		}
+		fmt.Fprintf(w, "for _, %s := range %s {\n%s := %s[%s]\n", key, keys, elem, v, key)
+		fmt.Fprintf(w, "%s := validator.Result{}\n", elemRes)
+		if err := g.emitValue(w, plan.dive, u.Elem(), elem, elemRes); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "if %s.IsFailure() {\n%s.AddFailureAt(fmt.Sprintf(\"[%%v]\", %s), %s)\n}\n}\n", elemRes, res, key, elemRes)
+	default:
+		g.use("fmt")
+		index := g.newVar("i")
+		fmt.Fprintf(w, "for %s, %s := range %s {\n", index, elem, v)
+		fmt.Fprintf(w, "%s := validator.Result{}\n", elemRes)
+		if err := g.emitValue(w, plan.dive, elemOf(t), elem, elemRes); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "if %s.IsFailure() {\n%s.AddFailureAt(fmt.Sprintf(\"[%%d]\", %s), %s)\n}\n}\n", elemRes, res, index, elemRes)
+	}
+
+	return nil
+}
+
+// zeroExpr returns the expression reporting whether the value in v of type t
+// is empty, as tags.FromTags checks it, or not empty when zero is false. The
+// top level strings, slices and maps are empty when their length is zero,
+// any other value when it is the zero value of its type
+func (g *generator) zeroExpr(v string, t types.Type, zero bool, top bool) (string, error) {
+	op, join, not := "==", " && ", "!"
+	if !zero {
+		op, join, not = "!=", " || ", ""
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		info := u.Info()
+		switch {
+		case info&types.IsString != 0:
+			return fmt.Sprintf("len(%s) %s 0", v, op), nil
+		case info&types.IsBoolean != 0:
+			return not + v, nil
+		case info&types.IsFloat != 0:
+			g.use("math")
+			return fmt.Sprintf("math.Float64bits(%s) %s 0", convert("float64", t)(v), op), nil
+		case info&types.IsComplex != 0:
+			g.use("math")
+			return fmt.Sprintf("(math.Float64bits(real(complex128(%[1]s))) %[2]s 0%[3]smath.Float64bits(imag(complex128(%[1]s))) %[2]s 0)", v, op, join), nil
+		}
+		return fmt.Sprintf("%s %s 0", v, op), nil
+	case *types.Slice, *types.Map:
+		if top {
+			return fmt.Sprintf("len(%s) %s 0", v, op), nil
+		}
+		return fmt.Sprintf("%s %s nil", v, op), nil
+	case *types.Pointer, *types.Chan, *types.Signature, *types.Interface:
+		return fmt.Sprintf("%s %s nil", v, op), nil
+	case *types.Struct:
+		if accessible(g.pkg, u) {
+			if u.NumFields() == 0 {
+				return fmt.Sprint(zero), nil
+			}
+
+			parts := make([]string, u.NumFields())
+			for i := 0; i < u.NumFields(); i++ {
+				part, err := g.zeroExpr(v+"."+u.Field(i).Name(), u.Field(i).Type(), zero, false)
+				if err != nil {
+					return "", err
+				}
+				parts[i] = part
+			}
+			return "(" + strings.Join(parts, join) + ")", nil
+		}
+	}
+
+	if !types.Comparable(t) {
+		return "", fmt.Errorf("cannot check whether a %s is empty", g.typeString(t))
+	}
+	return fmt.Sprintf("%s %s (%s{})", v, op, g.typeString(t)), nil
+}
+
+// accessible reports whether all the fields of st can be read from pkg
+func accessible(pkg *types.Package, st *types.Struct) bool {
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		if f.Name() == "_" || (!f.Exported() && f.Pkg() != pkg) {
+			return false
+		}
+	}
+	return true
+}
+
+// structFunc returns the name of the function validating the struct of plan
+func (g *generator) structFunc(plan *structPlan) string {
+	if plan.funcName == "" {
+		plan.funcName = g.funcName("validate" + plan.named.Obj().Name())
+	}
+	return plan.funcName
+}
+
+// funcName returns name, or name with a number when it is already used
+func (g *generator) funcName(name string) string {
+	unique := name
+	for i := 2; g.funcNames[unique] || g.pkg.Scope().Lookup(unique) != nil; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	g.funcNames[unique] = true
+	return unique
+}
+
+// ruleVar returns the name of the package variable holding the rule built by
+// expr, declaring it the first time
+func (g *generator) ruleVar(expr string) string {
+	g.use(rulesPath)
+	if name, ok := g.ruleNames[expr]; ok {
+		return name
+	}
+
+	name := g.funcName(fmt.Sprintf("%sRule%d", g.prefix, len(g.ruleExprs)))
+	g.ruleNames[expr] = name
+	g.ruleExprs = append(g.ruleExprs, expr)
+	return name
+}
+
+// newVar returns a new variable name of the field function being written
+func (g *generator) newVar(prefix string) string {
+	g.vars[prefix]++
+	return fmt.Sprintf("%s%d", prefix, g.vars[prefix])
+}
+
+func (g *generator) use(path string) {
+	name := path[strings.LastIndex(path, "/")+1:]
+	g.imports[path] = name
+}
+
+// typeString returns the type t as written in the generated file, adding the
+// imports of the packages it refers to
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == g.pkg {
+			return ""
+		}
+		g.imports[p.Path()] = p.Name()
+		return p.Name()
+	})
+}
+
+func contains(plans []*structPlan, plan *structPlan) bool {
+	for _, p := range plans {
+		if p == plan {
+			return true
+		}
+	}
+	return false
+}
+
+func lowerFirst(s string) string {
+	for i, r := range s {
+		return string(unicode.ToLower(r)) + s[i+len(string(r)):]
+	}
+	return s
+}
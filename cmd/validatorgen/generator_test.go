@@ -0,0 +1,115 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func Test_Generate_WhenExamplePackage_ShouldMatchGoldenFile(t *testing.T) {
+	// Arrange
+	dir := filepath.Join("internal", "example")
+	golden := filepath.Join(dir, "customer_validator.go")
+
+	// Act
+	src, err := generate(dir, []string{"Customer", "Order"})
+
+	// Assert
+	if !assert.NoError(t, err) {
+		return
+	}
+	if *update {
+		assert.NoError(t, os.WriteFile(golden, src, 0o644))
+	}
+	want, err := os.ReadFile(golden)
+	assert.NoError(t, err)
+	assert.Equal(t, string(want), string(src))
+}
+
+func Test_Generate_WhenTypesAreNotSupported_ShouldFail(t *testing.T) {
+	tests := []struct {
+		name  string
+		src   string
+		types []string
+		err   string
+	}{
+		{
+			name:  "unknown type",
+			src:   "type A struct{}",
+			types: []string{"B"},
+			err:   "type B not found in package p",
+		},
+		{
+			name:  "not a struct",
+			src:   "type A int",
+			types: []string{"A"},
+			err:   "A is not a struct",
+		},
+		{
+			name:  "unknown rule",
+			src:   "type A struct {\n\tName string `validate:\"nope\"`\n}",
+			types: []string{"A"},
+			err:   `A.Name: rule "nope": unknown rule`,
+		},
+		{
+			name:  "rule not applicable",
+			src:   "type A struct {\n\tAge int `validate:\"email\"`\n}",
+			types: []string{"A"},
+			err:   `A.Age: rule "email": cannot be applied to int`,
+		},
+		{
+			name:  "invalid tag",
+			src:   "type A struct {\n\tName string `validate:\"required,,max=3\"`\n}",
+			types: []string{"A"},
+			err:   `A.Name: empty rule in tag "required,,max=3"`,
+		},
+		{
+			name:  "interface",
+			src:   "type A struct {\n\tValue any `validate:\"required\"`\n}",
+			types: []string{"A"},
+			err:   "A.Value: interfaces are not supported",
+		},
+		{
+			name:  "anonymous struct",
+			src:   "type A struct {\n\tInner struct {\n\t\tName string `validate:\"required\"`\n\t}\n}",
+			types: []string{"A"},
+			err:   "A.Inner: anonymous structs with validate tags are not supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			dir := t.TempDir()
+			src := "package p\n\n" + tt.src + "\n"
+			assert.NoError(t, os.WriteFile(filepath.Join(dir, "p.go"), []byte(src), 0o644))
+
+			// Act
+			_, err := generate(dir, tt.types)
+
+			// Assert
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func Test_Generate_WhenOutputExists_ShouldIgnoreIt(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	src := "package p\n\ntype A struct {\n\tName string `validate:\"required\"`\n}\n"
+	stale := header + "\n\npackage p\n\nfunc validateA() {}\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "p.go"), []byte(src), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "a_validator.go"), []byte(stale), 0o644))
+
+	// Act
+	out, err := generate(dir, []string{"A"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "func validateA(src A) validator.Result {")
+}
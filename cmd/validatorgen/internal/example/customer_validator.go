@@ -0,0 +1,518 @@
+// Code generated by validatorgen. DO NOT EDIT.
+
+package example
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/cgxarrie-go/validator/rules"
+	"github.com/cgxarrie-go/validator/tags"
+)
+
+var (
+	customerRule0  = rules.HasPrefix("ref-")
+	customerRule1  = rules.MaxLength(20)
+	customerRule2  = rules.Email()
+	customerRule3  = rules.URL("http", "https")
+	customerRule4  = rules.UUID(4)
+	customerRule5  = rules.Min[int64](18)
+	customerRule6  = rules.Max[int64](130)
+	customerRule7  = rules.Finite[float64]()
+	customerRule8  = rules.MaxDecimalPlaces[float64](2)
+	customerRule9  = rules.GreaterThan[float64](0)
+	customerRule10 = rules.LessThan[float64](1)
+	customerRule11 = rules.OneOf("1", "2", "3")
+	customerRule12 = rules.Port()
+	customerRule13 = rules.MaxCount[any](3)
+	customerRule14 = rules.Unique[string]()
+	customerRule15 = rules.Alphanumeric()
+	customerRule16 = rules.MinCount[any](2)
+	customerRule17 = rules.MaxCount[any](2)
+	customerRule18 = rules.Lowercase()
+	customerRule19 = rules.MultipleOf[uint64](2)
+	customerRule20 = rules.NotBlank()
+	customerRule21 = rules.MaxLength(40)
+	customerRule22 = rules.Length(5)
+	customerRule23 = rules.Matches(regexp.MustCompile("^[0-9]+$"))
+	customerRule24 = rules.OneOf("ES", "FR", "PT")
+	customerRule25 = rules.NonZero[uint64]()
+	customerRule26 = rules.Uppercase()
+	customerRule27 = rules.Positive[uint64]()
+	customerRule28 = rules.Max[uint64](100)
+	customerRule29 = rules.Min[float64](0)
+	customerRule30 = rules.MaxLength(10)
+	customerRule31 = rules.MinCount[any](1)
+	customerRule32 = rules.Negative[int64]()
+)
+
+// CustomerValidator validates Customer values with the rules in their validate
+// tags. It reports the same failures as the validator built by
+// tags.FromTags, without reflection
+type CustomerValidator struct{}
+
+// Validate validates src
+func (CustomerValidator) Validate(src Customer) validator.Result {
+	return validateCustomer(src)
+}
+
+var _ validator.Validator[Customer] = CustomerValidator{}
+
+// OrderValidator validates Order values with the rules in their validate
+// tags. It reports the same failures as the validator built by
+// tags.FromTags, without reflection
+type OrderValidator struct{}
+
+// Validate validates src
+func (OrderValidator) Validate(src Order) validator.Result {
+	return validateOrder(src)
+}
+
+var _ validator.Validator[Order] = OrderValidator{}
+
+func validateCustomer(src Customer) validator.Result {
+	result := validator.Result{}
+	if res := validateCustomerBase(src.Base); res.IsFailure() {
+		result.AddFailureAt("", res)
+	}
+	if res := validateCustomerName(src.Name); res.IsFailure() {
+		result.AddFailureAt("name", res)
+	}
+	if res := validateCustomerEmail(src.Email); res.IsFailure() {
+		result.AddFailureAt("email", res)
+	}
+	if res := validateCustomerWebsite(src.Website); res.IsFailure() {
+		result.AddFailureAt("website", res)
+	}
+	if res := validateCustomerID(src.ID); res.IsFailure() {
+		result.AddFailureAt("id", res)
+	}
+	if res := validateCustomerAge(src.Age); res.IsFailure() {
+		result.AddFailureAt("age", res)
+	}
+	if res := validateCustomerScore(src.Score); res.IsFailure() {
+		result.AddFailureAt("score", res)
+	}
+	if res := validateCustomerRatio(src.Ratio); res.IsFailure() {
+		result.AddFailureAt("ratio", res)
+	}
+	if res := validateCustomerLevel(src.Level); res.IsFailure() {
+		result.AddFailureAt("level", res)
+	}
+	if res := validateCustomerPort(src.Port); res.IsFailure() {
+		result.AddFailureAt("port", res)
+	}
+	if res := validateCustomerTags(src.Tags); res.IsFailure() {
+		result.AddFailureAt("tags", res)
+	}
+	if res := validateCustomerLabels(src.Labels); res.IsFailure() {
+		result.AddFailureAt("labels", res)
+	}
+	if res := validateCustomerCounts(src.Counts); res.IsFailure() {
+		result.AddFailureAt("counts", res)
+	}
+	if res := validateCustomerAddress(src.Address); res.IsFailure() {
+		result.AddFailureAt("address", res)
+	}
+	if res := validateCustomerPrevious(src.Previous); res.IsFailure() {
+		result.AddFailureAt("previous", res)
+	}
+	if res := validateCustomerReferrer(src.Referrer); res.IsFailure() {
+		result.AddFailureAt("referrer", res)
+	}
+	return result
+}
+
+func validateCustomerBase(v Base) validator.Result {
+	res := validator.Result{}
+	res.Merge(validateBase(v))
+	return res
+}
+
+func validateCustomerName(v string) validator.Result {
+	res := validator.Result{}
+	if len(v) == 0 {
+		res.AddFailure(tags.Required())
+	} else {
+		res.AddFailure(tags.Value(v, customerRule1.Check(v)))
+	}
+	return res
+}
+
+func validateCustomerEmail(v *string) validator.Result {
+	res := validator.Result{}
+	if v != nil {
+		v1 := *v
+		if len(v1) != 0 {
+			res.AddFailure(tags.Value(v1, customerRule2.Check(v1)))
+		}
+	}
+	return res
+}
+
+func validateCustomerWebsite(v string) validator.Result {
+	res := validator.Result{}
+	if len(v) != 0 {
+		res.AddFailure(tags.Value(v, customerRule3.Check(v)))
+	}
+	return res
+}
+
+func validateCustomerID(v string) validator.Result {
+	res := validator.Result{}
+	if len(v) != 0 {
+		res.AddFailure(tags.Value(v, customerRule4.Check(v)))
+	}
+	return res
+}
+
+func validateCustomerAge(v int) validator.Result {
+	res := validator.Result{}
+	res.AddFailure(tags.Value(v, customerRule5.Check(int64(v))))
+	res.AddFailure(tags.Value(v, customerRule6.Check(int64(v))))
+	return res
+}
+
+func validateCustomerScore(v float64) validator.Result {
+	res := validator.Result{}
+	if math.Float64bits(v) != 0 {
+		res.AddFailure(tags.Value(v, customerRule7.Check(v)))
+		res.AddFailure(tags.Value(v, customerRule8.Check(v)))
+	}
+	return res
+}
+
+func validateCustomerRatio(v float32) validator.Result {
+	res := validator.Result{}
+	res.AddFailure(tags.Value(v, customerRule9.Check(float64(v))))
+	res.AddFailure(tags.Value(v, customerRule10.Check(float64(v))))
+	return res
+}
+
+func validateCustomerLevel(v Level) validator.Result {
+	res := validator.Result{}
+	res.AddFailure(tags.Value(v, customerRule11.Check(strconv.FormatInt(int64(v), 10))))
+	return res
+}
+
+func validateCustomerPort(v uint16) validator.Result {
+	res := validator.Result{}
+	if v != 0 {
+		if port := uint64(v); port > 65535 {
+			res.AddFailure(tags.Value(v, customerRule12.Check(-1)))
+		} else {
+			res.AddFailure(tags.Value(v, customerRule12.Check(int(port))))
+		}
+	}
+	return res
+}
+
+func validateCustomerTags(v []string) validator.Result {
+	res := validator.Result{}
+	res.AddFailure(tags.Value(v, customerRule13.Check(make([]any, len(v)))))
+	res.AddFailure(customerRule14.Check(v))
+	for i1, e1 := range v {
+		res1 := validator.Result{}
+		res1.AddFailure(tags.Value(e1, customerRule15.Check(e1)))
+		if res1.IsFailure() {
+			res.AddFailureAt(fmt.Sprintf("[%d]", i1), res1)
+		}
+	}
+	return res
+}
+
+func validateCustomerLabels(v Labels) validator.Result {
+	res := validator.Result{}
+	if err := customerRule16.Check(make([]any, len(v))); err != nil {
+		res.AddFailure(tags.Value(v, err))
+	} else {
+		res.AddFailure(tags.Value(v, customerRule17.Check(make([]any, len(v)))))
+	}
+	keys1 := make([]string, 0, len(v))
+	for k1 := range v {
+		keys1 = append(keys1, k1)
+	}
+	sort.Slice(keys1, func(i, j int) bool {
+		return keys1[i] < keys1[j]
+	})
+	for _, k1 := range keys1 {
+		e1 := v[k1]
+		res1 := validator.Result{}
+		res1.AddFailure(tags.Value(e1, customerRule18.Check(e1)))
+		if res1.IsFailure() {
+			res.AddFailureAt(fmt.Sprintf("[%v]", k1), res1)
+		}
+	}
+	return res
+}
+
+func validateCustomerCounts(v [3]uint) validator.Result {
+	res := validator.Result{}
+	for i1, e1 := range v {
+		res1 := validator.Result{}
+		res1.AddFailure(tags.Value(e1, customerRule19.Check(uint64(e1))))
+		if res1.IsFailure() {
+			res.AddFailureAt(fmt.Sprintf("[%d]", i1), res1)
+		}
+	}
+	return res
+}
+
+func validateCustomerAddress(v *Address) validator.Result {
+	res := validator.Result{}
+	if v == nil {
+		res.AddFailure(tags.Required())
+	} else {
+		v1 := *v
+		if len(v1.Street) == 0 && len(v1.Zip) == 0 && len(v1.Country) == 0 {
+			res.AddFailure(tags.Required())
+		} else {
+			res.Merge(validateAddress(v1))
+		}
+	}
+	return res
+}
+
+func validateCustomerPrevious(v []Address) validator.Result {
+	res := validator.Result{}
+	res.AddFailure(tags.Value(v, customerRule17.Check(make([]any, len(v)))))
+	for i1, e1 := range v {
+		res1 := validator.Result{}
+		res1.Merge(validateAddress(e1))
+		if res1.IsFailure() {
+			res.AddFailureAt(fmt.Sprintf("[%d]", i1), res1)
+		}
+	}
+	return res
+}
+
+func validateCustomerReferrer(v *Customer) validator.Result {
+	res := validator.Result{}
+	if v != nil {
+		v1 := *v
+		res.Merge(validateCustomer(v1))
+	}
+	return res
+}
+
+func validateBase(src Base) validator.Result {
+	result := validator.Result{}
+	if res := validateBaseRef(src.Ref); res.IsFailure() {
+		result.AddFailureAt("ref", res)
+	}
+	return result
+}
+
+func validateBaseRef(v string) validator.Result {
+	res := validator.Result{}
+	if len(v) == 0 {
+		res.AddFailure(tags.Required())
+	} else {
+		res.AddFailure(tags.Value(v, customerRule0.Check(v)))
+	}
+	return res
+}
+
+func validateAddress(src Address) validator.Result {
+	result := validator.Result{}
+	if res := validateAddressStreet(src.Street); res.IsFailure() {
+		result.AddFailureAt("street", res)
+	}
+	if res := validateAddressZip(src.Zip); res.IsFailure() {
+		result.AddFailureAt("zip", res)
+	}
+	if res := validateAddressCountry(src.Country); res.IsFailure() {
+		result.AddFailureAt("country", res)
+	}
+	return result
+}
+
+func validateAddressStreet(v string) validator.Result {
+	res := validator.Result{}
+	if len(v) == 0 {
+		res.AddFailure(tags.Required())
+	} else {
+		res.AddFailure(tags.Value(v, customerRule20.Check(v)))
+		res.AddFailure(tags.Value(v, customerRule21.Check(v)))
+	}
+	return res
+}
+
+func validateAddressZip(v string) validator.Result {
+	res := validator.Result{}
+	res.AddFailure(tags.Value(v, customerRule22.Check(v)))
+	res.AddFailure(tags.Value(v, customerRule23.Check(v)))
+	return res
+}
+
+func validateAddressCountry(v string) validator.Result {
+	res := validator.Result{}
+	res.AddFailure(tags.Value(v, customerRule24.Check(v)))
+	return res
+}
+
+func validateOrder(src Order) validator.Result {
+	result := validator.Result{}
+	if res := validateOrderID(src.ID); res.IsFailure() {
+		result.AddFailureAt("id", res)
+	}
+	if res := validateOrderLines(src.Lines); res.IsFailure() {
+		result.AddFailureAt("lines", res)
+	}
+	if res := validateOrderNotes(src.Notes); res.IsFailure() {
+		result.AddFailureAt("notes", res)
+	}
+	if res := validateOrderPlaced(src.Placed); res.IsFailure() {
+		result.AddFailureAt("placed", res)
+	}
+	if res := validateOrderTotals(src.Totals); res.IsFailure() {
+		result.AddFailureAt("totals", res)
+	}
+	if res := validateOrderAddress(src.Address); res.IsFailure() {
+		result.AddFailureAt("address", res)
+	}
+	return result
+}
+
+func validateOrderID(v uint64) validator.Result {
+	res := validator.Result{}
+	res.AddFailure(tags.Value(v, customerRule25.Check(v)))
+	return res
+}
+
+func validateOrderLines(v []*Line) validator.Result {
+	res := validator.Result{}
+	if len(v) == 0 {
+		res.AddFailure(tags.Required())
+	} else {
+		for i1, e1 := range v {
+			res1 := validator.Result{}
+			if e1 == nil {
+				res1.AddFailure(tags.Required())
+			} else {
+				v1 := *e1
+				if len(v1.SKU) == 0 && v1.Quantity == 0 && math.Float64bits(v1.Price) == 0 {
+					res1.AddFailure(tags.Required())
+				} else {
+					res1.Merge(validateLine(v1))
+				}
+			}
+			if res1.IsFailure() {
+				res.AddFailureAt(fmt.Sprintf("[%d]", i1), res1)
+			}
+		}
+	}
+	return res
+}
+
+func validateOrderNotes(v map[int]*string) validator.Result {
+	res := validator.Result{}
+	keys1 := make([]int, 0, len(v))
+	for k1 := range v {
+		keys1 = append(keys1, k1)
+	}
+	sort.Slice(keys1, func(i, j int) bool {
+		return keys1[i] < keys1[j]
+	})
+	for _, k1 := range keys1 {
+		e1 := v[k1]
+		res1 := validator.Result{}
+		if e1 != nil {
+			v1 := *e1
+			if len(v1) != 0 {
+				res1.AddFailure(tags.Value(v1, customerRule30.Check(v1)))
+			}
+		}
+		if res1.IsFailure() {
+			res.AddFailureAt(fmt.Sprintf("[%v]", k1), res1)
+		}
+	}
+	return res
+}
+
+func validateOrderPlaced(v time.Time) validator.Result {
+	res := validator.Result{}
+	if v == (time.Time{}) {
+		res.AddFailure(tags.Required())
+	}
+	return res
+}
+
+func validateOrderTotals(v map[string][]int) validator.Result {
+	res := validator.Result{}
+	keys1 := make([]string, 0, len(v))
+	for k1 := range v {
+		keys1 = append(keys1, k1)
+	}
+	sort.Slice(keys1, func(i, j int) bool {
+		return keys1[i] < keys1[j]
+	})
+	for _, k1 := range keys1 {
+		e1 := v[k1]
+		res1 := validator.Result{}
+		res1.AddFailure(tags.Value(e1, customerRule31.Check(make([]any, len(e1)))))
+		for i1, e2 := range e1 {
+			res2 := validator.Result{}
+			res2.AddFailure(tags.Value(e2, customerRule32.Check(int64(e2))))
+			if res2.IsFailure() {
+				res1.AddFailureAt(fmt.Sprintf("[%d]", i1), res2)
+			}
+		}
+		if res1.IsFailure() {
+			res.AddFailureAt(fmt.Sprintf("[%v]", k1), res1)
+		}
+	}
+	return res
+}
+
+func validateOrderAddress(v Address) validator.Result {
+	res := validator.Result{}
+	if len(v.Street) == 0 && len(v.Zip) == 0 && len(v.Country) == 0 {
+		res.AddFailure(tags.Required())
+	} else {
+		res.Merge(validateAddress(v))
+	}
+	return res
+}
+
+func validateLine(src Line) validator.Result {
+	result := validator.Result{}
+	if res := validateLineSKU(src.SKU); res.IsFailure() {
+		result.AddFailureAt("sku", res)
+	}
+	if res := validateLineQuantity(src.Quantity); res.IsFailure() {
+		result.AddFailureAt("quantity", res)
+	}
+	if res := validateLinePrice(src.Price); res.IsFailure() {
+		result.AddFailureAt("price", res)
+	}
+	return result
+}
+
+func validateLineSKU(v string) validator.Result {
+	res := validator.Result{}
+	if len(v) == 0 {
+		res.AddFailure(tags.Required())
+	} else {
+		res.AddFailure(tags.Value(v, customerRule26.Check(v)))
+	}
+	return res
+}
+
+func validateLineQuantity(v uint) validator.Result {
+	res := validator.Result{}
+	res.AddFailure(tags.Value(v, customerRule27.Check(uint64(v))))
+	res.AddFailure(tags.Value(v, customerRule28.Check(uint64(v))))
+	return res
+}
+
+func validateLinePrice(v float64) validator.Result {
+	res := validator.Result{}
+	res.AddFailure(tags.Value(v, customerRule29.Check(v)))
+	return res
+}
@@ -0,0 +1,58 @@
+// Package example holds the types used to check that the validators generated
+// by validatorgen agree with the validators built by tags.FromTags
+package example
+
+import "time"
+
+//go:generate go run github.com/cgxarrie-go/validator/cmd/validatorgen -type=Customer,Order
+
+type Level int
+
+type Labels map[string]string
+
+type Base struct {
+	Ref string `json:"ref" validate:"required,prefix=ref-"`
+}
+
+type Address struct {
+	Street  string `json:"street" validate:"required,notblank,max=40"`
+	Zip     string `json:"zip" validate:"len=5,pattern=^[0-9]+$"`
+	Country string `json:"country" validate:"oneof=ES FR PT"`
+}
+
+type Customer struct {
+	Base
+	Name     string    `json:"name" validate:"required,max=20"`
+	Email    *string   `json:"email" validate:"omitempty,email"`
+	Website  string    `json:"website" validate:"omitempty,url=http https"`
+	ID       string    `json:"id" validate:"omitempty,uuid=4"`
+	Age      int       `json:"age" validate:"gte=18,lte=130"`
+	Score    float64   `json:"score" validate:"omitempty,finite,decimals=2"`
+	Ratio    float32   `json:"ratio" validate:"gt=0,lt=1"`
+	Level    Level     `json:"level" validate:"oneof=1 2 3"`
+	Port     uint16    `json:"port" validate:"omitempty,port"`
+	Tags     []string  `json:"tags" validate:"max=3,unique,dive,alphanum"`
+	Labels   Labels    `json:"labels" validate:"len=2,dive,lowercase"`
+	Counts   [3]uint   `json:"counts" validate:"dive,multipleof=2"`
+	Address  *Address  `json:"address" validate:"required"`
+	Previous []Address `json:"previous" validate:"max=2,dive"`
+	Referrer *Customer `json:"referrer"`
+	Skipped  string    `validate:"-"`
+	internal string
+}
+
+type Line struct {
+	SKU      string  `json:"sku" validate:"required,uppercase"`
+	Quantity uint    `json:"quantity" validate:"positive,max=100"`
+	Price    float64 `json:"price" validate:"gte=0"`
+}
+
+type Order struct {
+	ID      uint64           `json:"id" validate:"nonzero"`
+	Lines   []*Line          `json:"lines" validate:"required,dive,required"`
+	Notes   map[int]*string  `json:"notes" validate:"dive,omitempty,max=10"`
+	Placed  time.Time        `json:"placed" validate:"required"`
+	Shipped *time.Time       `json:"shipped"`
+	Totals  map[string][]int `json:"totals" validate:"dive,min=1,dive,negative"`
+	Address Address          `json:"address" validate:"required"`
+}
@@ -0,0 +1,192 @@
+package example_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/cgxarrie-go/validator/cmd/validatorgen/internal/example"
+	"github.com/cgxarrie-go/validator/tags"
+	"github.com/stretchr/testify/assert"
+)
+
+func validCustomer() example.Customer {
+	email := "jane@example.com"
+	return example.Customer{
+		Base:    example.Base{Ref: "ref-1"},
+		Name:    "Jane",
+		Email:   &email,
+		Website: "https://example.com",
+		ID:      "9b2f3c4e-1a2b-4c3d-8e9f-0a1b2c3d4e5f",
+		Age:     30,
+		Score:   9.75,
+		Ratio:   0.5,
+		Level:   2,
+		Port:    8080,
+		Tags:    []string{"a1", "b2"},
+		Labels:  example.Labels{"env": "prod", "team": "core"},
+		Counts:  [3]uint{2, 4, 6},
+		Address: &example.Address{Street: "Main St", Zip: "08001", Country: "ES"},
+	}
+}
+
+func validOrder() example.Order {
+	note := "fragile"
+	return example.Order{
+		ID:      1,
+		Lines:   []*example.Line{{SKU: "AB1", Quantity: 2, Price: 9.5}},
+		Notes:   map[int]*string{1: &note},
+		Placed:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Totals:  map[string][]int{"refunds": {-1, -2}},
+		Address: example.Address{Street: "Main St", Zip: "08001", Country: "FR"},
+	}
+}
+
+func Test_CustomerValidator_WhenValidatingCustomers_ShouldAgreeWithTags(t *testing.T) {
+	invalidEmail := "not an email"
+	blank := ""
+
+	tests := []struct {
+		name   string
+		change func(c *example.Customer)
+	}{
+		{name: "valid", change: func(c *example.Customer) {}},
+		{name: "zero value", change: func(c *example.Customer) { *c = example.Customer{} }},
+		{name: "embedded", change: func(c *example.Customer) { c.Ref = "1" }},
+		{name: "embedded missing", change: func(c *example.Customer) { c.Ref = "" }},
+		{name: "long name", change: func(c *example.Customer) { c.Name = "Jane Jane Jane Jane Jane" }},
+		{name: "invalid email", change: func(c *example.Customer) { c.Email = &invalidEmail }},
+		{name: "blank email", change: func(c *example.Customer) { c.Email = &blank }},
+		{name: "nil email", change: func(c *example.Customer) { c.Email = nil }},
+		{name: "website scheme", change: func(c *example.Customer) { c.Website = "ftp://example.com" }},
+		{name: "uuid version", change: func(c *example.Customer) { c.ID = "9b2f3c4e-1a2b-1c3d-8e9f-0a1b2c3d4e5f" }},
+		{name: "young", change: func(c *example.Customer) { c.Age = 10 }},
+		{name: "old", change: func(c *example.Customer) { c.Age = 200 }},
+		{name: "score decimals", change: func(c *example.Customer) { c.Score = 1.125 }},
+		{name: "score infinite", change: func(c *example.Customer) { c.Score = math.Inf(1) }},
+		{name: "score negative zero", change: func(c *example.Customer) { c.Score = math.Copysign(0, -1) }},
+		{name: "ratio out of range", change: func(c *example.Customer) { c.Ratio = 1.5 }},
+		{name: "level", change: func(c *example.Customer) { c.Level = 7 }},
+		{name: "port", change: func(c *example.Customer) { c.Port = 0 }},
+		{name: "too many tags", change: func(c *example.Customer) { c.Tags = []string{"a", "b", "c", "d"} }},
+		{name: "duplicated tags", change: func(c *example.Customer) { c.Tags = []string{"a", "b", "a"} }},
+		{name: "invalid tags", change: func(c *example.Customer) { c.Tags = []string{"a-1", "b", "c d"} }},
+		{name: "labels count", change: func(c *example.Customer) { c.Labels = example.Labels{"env": "prod"} }},
+		{name: "labels case", change: func(c *example.Customer) { c.Labels = example.Labels{"b": "UP", "a": "Down"} }},
+		{name: "nil labels", change: func(c *example.Customer) { c.Labels = nil }},
+		{name: "odd counts", change: func(c *example.Customer) { c.Counts = [3]uint{1, 2, 3} }},
+		{name: "nil address", change: func(c *example.Customer) { c.Address = nil }},
+		{name: "empty address", change: func(c *example.Customer) { c.Address = &example.Address{} }},
+		{name: "invalid address", change: func(c *example.Customer) {
+			c.Address = &example.Address{Street: "  ", Zip: "08A", Country: "IT"}
+		}},
+		{name: "previous addresses", change: func(c *example.Customer) {
+			c.Previous = []example.Address{{}, {Street: "Elm St", Zip: "12345", Country: "PT"}, {Zip: "1"}}
+		}},
+		{name: "referrer", change: func(c *example.Customer) {
+			referrer := validCustomer()
+			referrer.Name = ""
+			referrer.Referrer = &example.Customer{Age: 5}
+			c.Referrer = &referrer
+		}},
+	}
+
+	runtime := tags.MustFromTags[example.Customer]()
+	generated := example.CustomerValidator{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			c := validCustomer()
+			tt.change(&c)
+
+			// Act
+			want := runtime.Validate(c)
+			got := generated.Validate(c)
+
+			// Assert
+			assert.Equal(t, want.Failures(), got.Failures())
+			assert.Equal(t, want.IsSuccess(), got.IsSuccess())
+		})
+	}
+}
+
+func Test_OrderValidator_WhenValidatingOrders_ShouldAgreeWithTags(t *testing.T) {
+	long := "this note is too long"
+	blank := ""
+
+	tests := []struct {
+		name   string
+		change func(o *example.Order)
+	}{
+		{name: "valid", change: func(o *example.Order) {}},
+		{name: "zero value", change: func(o *example.Order) { *o = example.Order{} }},
+		{name: "no lines", change: func(o *example.Order) { o.Lines = []*example.Line{} }},
+		{name: "nil line", change: func(o *example.Order) { o.Lines = append(o.Lines, nil) }},
+		{name: "empty line", change: func(o *example.Order) { o.Lines = append(o.Lines, &example.Line{}) }},
+		{name: "invalid line", change: func(o *example.Order) {
+			o.Lines = append(o.Lines, &example.Line{SKU: "ab", Quantity: 101, Price: -1})
+		}},
+		{name: "notes", change: func(o *example.Order) {
+			o.Notes = map[int]*string{10: &long, 2: &long, 3: nil, 4: &blank}
+		}},
+		{name: "totals", change: func(o *example.Order) {
+			o.Totals = map[string][]int{"b": {}, "a": {1, -1, 0}}
+		}},
+		{name: "empty address", change: func(o *example.Order) { o.Address = example.Address{} }},
+		{name: "shipped", change: func(o *example.Order) {
+			shipped := time.Time{}
+			o.Shipped = &shipped
+		}},
+	}
+
+	runtime := tags.MustFromTags[example.Order]()
+	generated := example.OrderValidator{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			o := validOrder()
+			tt.change(&o)
+
+			// Act
+			want := runtime.Validate(o)
+			got := generated.Validate(o)
+
+			// Assert
+			assert.Equal(t, want.Failures(), got.Failures())
+			assert.Equal(t, want.IsSuccess(), got.IsSuccess())
+		})
+	}
+}
+
+func Test_CustomerValidator_WhenCustomerIsValid_ShouldSucceed(t *testing.T) {
+	// Arrange
+	c := validCustomer()
+
+	// Act
+	result := example.CustomerValidator{}.Validate(c)
+
+	// Assert
+	assert.True(t, result.IsSuccess(), result.Error())
+}
+
+func Test_CustomerValidator_WhenCustomerIsZero_ShouldReportFailuresWithPaths(t *testing.T) {
+	// Arrange
+	c := example.Customer{}
+
+	// Act
+	result := example.CustomerValidator{}.Validate(c)
+
+	// Assert
+	assert.Equal(t, []string{"ref", "name", "age", "ratio", "level", "labels", "address"}, paths(result.Failures()))
+}
+
+func paths(failures []validator.Failure) []string {
+	paths := make([]string, len(failures))
+	for i, f := range failures {
+		paths[i] = f.Path
+	}
+	return paths
+}
@@ -0,0 +1,75 @@
+// Command validatorgen generates validators from the validate tags of struct
+// types, as an alternative to tags.FromTags that does not use reflection.
+//
+// For every type given in -type, it writes a <Type>Validator implementing
+// validator.Validator[<Type>] that reports the same failures as the validator
+// built by tags.FromTags. It is meant to be run by go generate:
+//
+//	//go:generate go run github.com/cgxarrie-go/validator/cmd/validatorgen -type=Customer,Order
+//
+// Usage:
+//
+//	validatorgen -type=T[,T...] [-output=file] [dir]
+//
+// The package in dir, the current directory by default, is read from source.
+// The output is written to <type>_validator.go in the same directory, where
+// <type> is the first type in lower case, unless -output is given.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("validatorgen: ")
+
+	typeNames := flag.String("type", "", "comma separated list of type names; must be set")
+	output := flag.String("output", "", "output file name; default <type>_validator.go")
+	flag.Usage = usage
+	flag.Parse()
+
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	names := strings.Split(*typeNames, ",")
+	for i, name := range names {
+		names[i] = strings.TrimSpace(name)
+	}
+
+	src, err := generate(dir, names)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	file := *output
+	if file == "" {
+		file = strings.ToLower(names[0]) + "_validator.go"
+	}
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(dir, file)
+	}
+
+	if err := os.WriteFile(file, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of validatorgen:\n")
+	fmt.Fprintf(os.Stderr, "\tvalidatorgen -type=T[,T...] [-output=file] [dir]\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+
+	"github.com/cgxarrie-go/validator/tags"
+)
+
+// The plans mirror the plans compiled by the tags package, so the generated
+// code checks the same rules in the same order
+
+type structPlan struct {
+	named     *types.Named
+	fields    []*fieldPlan
+	compiling bool
+	funcName  string
+}
+
+type fieldPlan struct {
+	goName   string
+	name     string
+	typ      types.Type
+	value    *valuePlan
+	funcName string
+}
+
+type valuePlan struct {
+	required  bool
+	omitEmpty bool
+	rules     []rule
+	nested    *structPlan
+	dive      *valuePlan
+}
+
+func (g *generator) compileStruct(named *types.Named) (*structPlan, error) {
+	if plan, ok := g.structs[named]; ok {
+		return plan, nil
+	}
+
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a struct", g.typeString(named))
+	}
+
+	plan := &structPlan{named: named, compiling: true}
+	g.structs[named] = plan
+	g.order = append(g.order, plan)
+	defer func() { plan.compiling = false }()
+
+	fields, err := g.compileFields(named, st)
+	if err != nil {
+		return nil, err
+	}
+	plan.fields = fields
+
+	if len(fields) > 0 && named.Obj().Pkg() != g.pkg {
+		return nil, fmt.Errorf("%s has validate tags and is not declared in package %s", g.typeString(named), g.pkg.Name())
+	}
+	return plan, nil
+}
+
+func (g *generator) compileFields(owner types.Type, st *types.Struct) ([]*fieldPlan, error) {
+	fields := make([]*fieldPlan, 0)
+
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		if !f.Exported() {
+			continue
+		}
+
+		tag := reflect.StructTag(st.Tag(i))
+		if tag.Get(tags.TagName) == "-" {
+			continue
+		}
+
+		specs, err := tags.Parse(tag.Get(tags.TagName))
+		if err != nil {
+			return nil, g.fieldError(owner, f, "", err.Error())
+		}
+
+		if basic, ok := f.Type().(*types.Basic); ok && basic.Kind() == types.Invalid {
+			return nil, g.fieldError(owner, f, "", "has an invalid type")
+		}
+
+		value, err := g.compileValue(owner, f, f.Type(), specs)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			continue
+		}
+
+		fields = append(fields, &fieldPlan{
+			goName: f.Name(),
+			name: tags.FieldName(reflect.StructField{
+				Name:      f.Name(),
+				Tag:       tag,
+				Anonymous: f.Anonymous(),
+			}),
+			typ:   f.Type(),
+			value: value,
+		})
+	}
+
+	return fields, nil
+}
+
+func (g *generator) compileValue(owner types.Type, f *types.Var, t types.Type, specs []tags.Spec) (*valuePlan, error) {
+	plan := &valuePlan{}
+
+	base := t
+	for {
+		ptr, ok := base.Underlying().(*types.Pointer)
+		if !ok {
+			break
+		}
+		base = ptr.Elem()
+	}
+
+	for i, spec := range specs {
+		switch spec.Name {
+		case "required":
+			plan.required = true
+		case "omitempty":
+			plan.omitEmpty = true
+		case "dive":
+			elem := elemOf(base)
+			if elem == nil {
+				return nil, g.fieldError(owner, f, spec.Name, fmt.Sprintf("cannot be applied to %s", g.typeString(base)))
+			}
+
+			dive, err := g.compileValue(owner, f, elem, specs[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			plan.dive = dive
+			return g.finish(owner, f, plan, base)
+		default:
+			rule, err := g.buildRule(spec, base)
+			if err != nil {
+				return nil, g.fieldError(owner, f, spec.Name, err.Error())
+			}
+			plan.rules = append(plan.rules, rule)
+		}
+	}
+
+	return g.finish(owner, f, plan, base)
+}
+
+// finish attaches the plan of base to plan when base is a struct, and returns
+// nil when there is nothing to validate
+func (g *generator) finish(owner types.Type, f *types.Var, plan *valuePlan, base types.Type) (*valuePlan, error) {
+	if st, ok := base.Underlying().(*types.Struct); ok {
+		named, ok := base.(*types.Named)
+		if !ok {
+			fields, err := g.compileFields(base, st)
+			if err != nil {
+				return nil, err
+			}
+			if len(fields) > 0 {
+				return nil, g.fieldError(owner, f, "", "anonymous structs with validate tags are not supported")
+			}
+		} else {
+			nested, err := g.compileStruct(named)
+			if err != nil {
+				return nil, err
+			}
+			if len(nested.fields) > 0 || nested.compiling {
+				plan.nested = nested
+			}
+		}
+	}
+
+	if !plan.required && len(plan.rules) == 0 && plan.nested == nil && plan.dive == nil {
+		return nil, nil
+	}
+
+	if _, ok := base.Underlying().(*types.Interface); ok {
+		return nil, g.fieldError(owner, f, "", "interfaces are not supported")
+	}
+	return plan, nil
+}
+
+func (g *generator) fieldError(owner types.Type, f *types.Var, rule string, msg string) error {
+	if rule == "" {
+		return fmt.Errorf("%s.%s: %s", g.typeString(owner), f.Name(), msg)
+	}
+	return fmt.Errorf("%s.%s: rule %q: %s", g.typeString(owner), f.Name(), rule, msg)
+}
+
+// elemOf returns the type of the elements of a slice, array or map, or nil
+// for any other type
+func elemOf(t types.Type) types.Type {
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		return u.Elem()
+	case *types.Array:
+		return u.Elem()
+	case *types.Map:
+		return u.Elem()
+	}
+	return nil
+}
@@ -0,0 +1,444 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cgxarrie-go/validator/tags"
+)
+
+// rule returns the statements checking the value in v and adding its
+// failures to the result in res
+type rule func(v, res string) string
+
+type kindClass int
+
+const (
+	classOther kindClass = iota
+	classString
+	classInt
+	classUint
+	classFloat
+	classCollection
+)
+
+func classOf(t types.Type) kindClass {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		info := u.Info()
+		switch {
+		case info&types.IsString != 0:
+			return classString
+		case info&types.IsUnsigned != 0:
+			return classUint
+		case info&types.IsInteger != 0:
+			return classInt
+		case info&types.IsFloat != 0:
+			return classFloat
+		}
+	case *types.Slice, *types.Array, *types.Map:
+		return classCollection
+	}
+	return classOther
+}
+
+// stringRules holds the constructors in the rules package of the rules
+// without parameter that check strings
+var stringRules = map[string]string{
+	"notblank":  "NotBlank",
+	"alpha":     "Alpha",
+	"alphanum":  "Alphanumeric",
+	"ascii":     "ASCII",
+	"printable": "Printable",
+	"lowercase": "Lowercase",
+	"uppercase": "Uppercase",
+	"trimmed":   "Trimmed",
+	"utf8":      "ValidUTF8",
+	"email":     "Email",
+	"uri":       "URLReference",
+	"ip":        "IP",
+	"ipv4":      "IPv4",
+	"ipv6":      "IPv6",
+	"cidr":      "CIDR",
+	"hostname":  "Hostname",
+	"fqdn":      "FQDN",
+	"mac":       "MAC",
+}
+
+var errNoParam = errors.New("requires a parameter")
+
+// buildRule returns the rule for spec, checking values of type t, as
+// tags.FromTags builds it
+func (g *generator) buildRule(spec tags.Spec, t types.Type) (rule, error) {
+	class := classOf(t)
+	unsupported := fmt.Errorf("cannot be applied to %s", g.typeString(t))
+
+	if fn, ok := stringRules[spec.Name]; ok {
+		if spec.Param != "" {
+			return nil, errors.New("does not take a parameter")
+		}
+		if class != classString {
+			return nil, unsupported
+		}
+		return g.onString(t, "rules."+fn+"()"), nil
+	}
+
+	switch spec.Name {
+	case "min", "max", "len":
+		return g.buildBound(spec, t, class)
+
+	case "eq", "ne", "gt", "gte", "lt", "lte":
+		return g.buildComparison(spec, t, class)
+
+	case "oneof":
+		if spec.Param == "" {
+			return nil, errNoParam
+		}
+		values := strings.Fields(spec.Param)
+		switch class {
+		case classString:
+			return g.onString(t, "rules.OneOf("+quoteAll(values)+")"), nil
+		case classInt, classUint, classFloat:
+			for i, v := range values {
+				n, err := parseNumber(v, class)
+				if err != nil {
+					return nil, fmt.Errorf("invalid value %q for %s", v, g.typeString(t))
+				}
+				values[i] = n
+			}
+			return g.onValue("rules.OneOf("+quoteAll(values)+")", g.formatNumber(t, class)), nil
+		}
+		return nil, unsupported
+
+	case "pattern", "prefix", "suffix", "contains":
+		if spec.Param == "" {
+			return nil, errNoParam
+		}
+		if class != classString {
+			return nil, unsupported
+		}
+		switch spec.Name {
+		case "prefix":
+			return g.onString(t, "rules.HasPrefix("+quote(spec.Param)+")"), nil
+		case "suffix":
+			return g.onString(t, "rules.HasSuffix("+quote(spec.Param)+")"), nil
+		case "contains":
+			return g.onString(t, "rules.Contains("+quote(spec.Param)+")"), nil
+		}
+		if _, err := regexp.Compile(spec.Param); err != nil {
+			return nil, err
+		}
+		g.use("regexp")
+		return g.onString(t, "rules.Matches(regexp.MustCompile("+quote(spec.Param)+"))"), nil
+
+	case "url":
+		if class != classString {
+			return nil, unsupported
+		}
+		return g.onString(t, "rules.URL("+quoteAll(strings.Fields(spec.Param))+")"), nil
+
+	case "uuid":
+		if class != classString {
+			return nil, unsupported
+		}
+		versions := make([]string, 0)
+		for _, v := range strings.Fields(spec.Param) {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version %q", v)
+			}
+			versions = append(versions, strconv.Itoa(n))
+		}
+		return g.onString(t, "rules.UUID("+strings.Join(versions, ", ")+")"), nil
+
+	case "port":
+		if spec.Param != "" {
+			return nil, errors.New("does not take a parameter")
+		}
+		// values out of the int range are clamped to an invalid port
+		name := g.ruleVar("rules.Port()")
+		g.use(tagsPath)
+		switch class {
+		case classInt:
+			return func(v, res string) string {
+				return fmt.Sprintf("if port := int64(%[1]s); port < 0 || port > 65535 {\n"+
+					"%[2]s.AddFailure(tags.Value(%[1]s, %[3]s.Check(-1)))\n"+
+					"} else {\n"+
+					"%[2]s.AddFailure(tags.Value(%[1]s, %[3]s.Check(int(port))))\n"+
+					"}\n", v, res, name)
+			}, nil
+		case classUint:
+			return func(v, res string) string {
+				return fmt.Sprintf("if port := uint64(%[1]s); port > 65535 {\n"+
+					"%[2]s.AddFailure(tags.Value(%[1]s, %[3]s.Check(-1)))\n"+
+					"} else {\n"+
+					"%[2]s.AddFailure(tags.Value(%[1]s, %[3]s.Check(int(port))))\n"+
+					"}\n", v, res, name)
+			}, nil
+		}
+		return nil, unsupported
+
+	case "positive", "negative", "nonzero":
+		if spec.Param != "" {
+			return nil, errors.New("does not take a parameter")
+		}
+		fn := map[string]string{"positive": "Positive", "negative": "Negative", "nonzero": "NonZero"}[spec.Name]
+		switch class {
+		case classInt, classUint, classFloat:
+			n := numberType[class]
+			return g.onValue("rules."+fn+"["+n+"]()", convert(n, t)), nil
+		}
+		return nil, unsupported
+
+	case "multipleof":
+		n, err := strconv.ParseInt(spec.Param, 10, 64)
+		if err != nil || n == 0 {
+			return nil, fmt.Errorf("invalid parameter %q", spec.Param)
+		}
+		switch class {
+		case classInt:
+			return g.onValue(fmt.Sprintf("rules.MultipleOf[int64](%d)", n), convert("int64", t)), nil
+		case classUint:
+			if n < 0 {
+				return nil, fmt.Errorf("invalid parameter %q", spec.Param)
+			}
+			return g.onValue(fmt.Sprintf("rules.MultipleOf[uint64](%d)", n), convert("uint64", t)), nil
+		}
+		return nil, unsupported
+
+	case "finite", "decimals":
+		if class != classFloat {
+			return nil, unsupported
+		}
+		if spec.Name == "finite" {
+			return g.onValue("rules.Finite[float64]()", convert("float64", t)), nil
+		}
+		n, err := strconv.Atoi(spec.Param)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid parameter %q", spec.Param)
+		}
+		return g.onValue(fmt.Sprintf("rules.MaxDecimalPlaces[float64](%d)", n), convert("float64", t)), nil
+
+	case "unique":
+		var elem types.Type
+		slice := "%s"
+		switch u := t.Underlying().(type) {
+		case *types.Slice:
+			elem = u.Elem()
+		case *types.Array:
+			elem = u.Elem()
+			slice = "%s[:]"
+		default:
+			return nil, unsupported
+		}
+		if !types.Comparable(elem) {
+			return nil, fmt.Errorf("elements of %s are not comparable", g.typeString(t))
+		}
+
+		name := g.ruleVar("rules.Unique[" + g.typeString(elem) + "]()")
+		return func(v, res string) string {
+			return fmt.Sprintf("%s.AddFailure(%s.Check("+slice+"))\n", res, name, v)
+		}, nil
+	}
+
+	return nil, errors.New("unknown rule")
+}
+
+// buildBound builds the min, max and len rules, that check the length of
+// strings, the number of elements of collections and the value of numbers
+func (g *generator) buildBound(spec tags.Spec, t types.Type, class kindClass) (rule, error) {
+	if spec.Param == "" {
+		return nil, errNoParam
+	}
+
+	switch class {
+	case classString, classCollection:
+		n, err := strconv.Atoi(spec.Param)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid parameter %q", spec.Param)
+		}
+
+		if class == classString {
+			fn := map[string]string{"min": "MinLength", "max": "MaxLength", "len": "Length"}[spec.Name]
+			return g.onString(t, fmt.Sprintf("rules.%s(%d)", fn, n)), nil
+		}
+
+		count := func(v string) string { return "make([]any, len(" + v + "))" }
+		switch spec.Name {
+		case "min":
+			return g.onValue(fmt.Sprintf("rules.MinCount[any](%d)", n), count), nil
+		case "max":
+			return g.onValue(fmt.Sprintf("rules.MaxCount[any](%d)", n), count), nil
+		}
+
+		min := g.ruleVar(fmt.Sprintf("rules.MinCount[any](%d)", n))
+		max := g.ruleVar(fmt.Sprintf("rules.MaxCount[any](%d)", n))
+		g.use(tagsPath)
+		return func(v, res string) string {
+			return fmt.Sprintf("if err := %[3]s.Check(%[4]s); err != nil {\n"+
+				"%[2]s.AddFailure(tags.Value(%[1]s, err))\n"+
+				"} else {\n"+
+				"%[2]s.AddFailure(tags.Value(%[1]s, %[5]s.Check(%[4]s)))\n"+
+				"}\n", v, res, min, count(v), max)
+		}, nil
+	}
+
+	if spec.Name == "len" {
+		return nil, fmt.Errorf("cannot be applied to %s", g.typeString(t))
+	}
+
+	spec.Name = map[string]string{"min": "gte", "max": "lte"}[spec.Name]
+	return g.buildComparison(spec, t, class)
+}
+
+// buildComparison builds the rules comparing numbers, and strings for eq and
+// ne, with the value of the parameter
+func (g *generator) buildComparison(spec tags.Spec, t types.Type, class kindClass) (rule, error) {
+	if spec.Param == "" {
+		return nil, errNoParam
+	}
+
+	fn := map[string]string{
+		"eq":  "Equal",
+		"ne":  "NotEqual",
+		"gt":  "GreaterThan",
+		"gte": "Min",
+		"lt":  "LessThan",
+		"lte": "Max",
+	}[spec.Name]
+
+	if class == classString {
+		switch spec.Name {
+		case "eq", "ne":
+			return g.onString(t, "rules."+fn+"("+quote(spec.Param)+")"), nil
+		}
+		return nil, fmt.Errorf("cannot be applied to %s", g.typeString(t))
+	}
+
+	var literal string
+	switch class {
+	case classInt:
+		n, err := strconv.ParseInt(spec.Param, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameter %q", spec.Param)
+		}
+		literal = strconv.FormatInt(n, 10)
+	case classUint:
+		n, err := strconv.ParseUint(spec.Param, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameter %q", spec.Param)
+		}
+		literal = strconv.FormatUint(n, 10)
+	case classFloat:
+		n, err := strconv.ParseFloat(spec.Param, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameter %q", spec.Param)
+		}
+		literal = g.floatLiteral(n)
+	default:
+		return nil, fmt.Errorf("cannot be applied to %s", g.typeString(t))
+	}
+
+	n := numberType[class]
+	return g.onValue("rules."+fn+"["+n+"]("+literal+")", convert(n, t)), nil
+}
+
+// numberType is the type used to check the numbers of every class
+var numberType = map[kindClass]string{
+	classInt:   "int64",
+	classUint:  "uint64",
+	classFloat: "float64",
+}
+
+// parseNumber parses s as a number of the given class and returns it in the
+// format used by formatNumber
+func parseNumber(s string, class kindClass) (string, error) {
+	switch class {
+	case classInt:
+		n, err := strconv.ParseInt(s, 10, 64)
+		return strconv.FormatInt(n, 10), err
+	case classUint:
+		n, err := strconv.ParseUint(s, 10, 64)
+		return strconv.FormatUint(n, 10), err
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	return strconv.FormatFloat(n, 'g', -1, 64), err
+}
+
+// formatNumber returns the expression formatting a number as parseNumber
+func (g *generator) formatNumber(t types.Type, class kindClass) func(v string) string {
+	g.use("strconv")
+	return func(v string) string {
+		switch class {
+		case classInt:
+			return "strconv.FormatInt(" + convert("int64", t)(v) + ", 10)"
+		case classUint:
+			return "strconv.FormatUint(" + convert("uint64", t)(v) + ", 10)"
+		}
+		return "strconv.FormatFloat(" + convert("float64", t)(v) + ", 'g', -1, 64)"
+	}
+}
+
+// floatLiteral returns the expression of the float64 f
+func (g *generator) floatLiteral(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		g.use("math")
+		return "math.NaN()"
+	case math.IsInf(f, 1):
+		g.use("math")
+		return "math.Inf(1)"
+	case math.IsInf(f, -1):
+		g.use("math")
+		return "math.Inf(-1)"
+	case f == 0 && math.Signbit(f):
+		g.use("math")
+		return "math.Copysign(0, -1)"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// onString checks a rule built by expr on the value as a string
+func (g *generator) onString(t types.Type, expr string) rule {
+	return g.onValue(expr, convert("string", t))
+}
+
+// onValue checks a rule built by expr on the value converted by conv, and
+// sets the value as the value of its failure
+func (g *generator) onValue(expr string, conv func(v string) string) rule {
+	name := g.ruleVar(expr)
+	g.use(tagsPath)
+	return func(v, res string) string {
+		return fmt.Sprintf("%s.AddFailure(tags.Value(%s, %s.Check(%s)))\n", res, v, name, conv(v))
+	}
+}
+
+// convert returns a function converting a value of type t to the predeclared
+// type named to, when t is not that type already
+func convert(to string, t types.Type) func(v string) string {
+	if basic, ok := t.(*types.Basic); ok && basic.Name() == to {
+		return func(v string) string { return v }
+	}
+	return func(v string) string { return to + "(" + v + ")" }
+}
+
+// quote returns s as a Go string literal, raw when possible
+func quote(s string) string {
+	if strings.ContainsAny(s, "`\"\\") && strconv.CanBackquote(s) {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+func quoteAll(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = quote(v)
+	}
+	return strings.Join(quoted, ", ")
+}
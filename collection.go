@@ -0,0 +1,242 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/cgxarrie-go/validator/internal/keys"
+)
+
+// Failure codes produced by the built-in rules of a collection chain
+const (
+	CodeMinCount = "min_count"
+	CodeMaxCount = "max_count"
+)
+
+type element[E any] struct {
+	key   string
+	value E
+	// mapKey is the key of the element when it is found in a map
+	mapKey reflect.Value
+}
+
+type collectionChain[T any, C any, E any] struct {
+	owner      *validator[T]
+	step       *validationStep[T]
+	name       string
+	get        func(T) C
+	elements   func(C) []element[E]
+	rules      []Rule[C]
+	elemRules  []Rule[E]
+	validators []Validator[E]
+	// keys is true when the elements are the keys of a map
+	keys bool
+}
+
+// ForEach adds a validation step to v that checks every element of the slice
+// returned by get. Failures of an element are prefixed with the field name
+// and the element index, e.g. "items[3].name". Arrays are checked by
+// returning a slice of them from get, e.g. func(o order) []line { return o.Lines[:] }
+func ForEach[T any, E any](v *validator[T], name string, get func(T) []E) *collectionChain[T, []E, E] {
+	return newCollectionChain(v, name, get, func(items []E) []element[E] {
+		elements := make([]element[E], len(items))
+		for i, item := range items {
+			elements[i] = element[E]{key: fmt.Sprint(i), value: item}
+		}
+		return elements
+	})
+}
+
+// ForEachValue adds a validation step to v that checks every value of the
+// map returned by get. Failures of a value are prefixed with the field name
+// and the map key, e.g. "labels[env]". Values are checked in key order
+func ForEachValue[T any, K comparable, V any](v *validator[T], name string, get func(T) map[K]V) *collectionChain[T, map[K]V, V] {
+	return newCollectionChain(v, name, get, func(items map[K]V) []element[V] {
+		elements := make([]element[V], 0, len(items))
+		for k, item := range items {
+			elements = append(elements, element[V]{key: fmt.Sprint(k), value: item, mapKey: reflect.ValueOf(k)})
+		}
+		sortElements(elements)
+		return elements
+	})
+}
+
+// ForEachKey adds a validation step to v that checks every key of the map
+// returned by get. Failures of a key are prefixed with the field name and
+// the map key, e.g. "labels[env]". Keys are checked in order
+func ForEachKey[T any, K comparable, V any](v *validator[T], name string, get func(T) map[K]V) *collectionChain[T, map[K]V, K] {
+	chain := newCollectionChain(v, name, get, func(items map[K]V) []element[K] {
+		elements := make([]element[K], 0, len(items))
+		for k := range items {
+			elements = append(elements, element[K]{key: fmt.Sprint(k), value: k, mapKey: reflect.ValueOf(k)})
+		}
+		sortElements(elements)
+		return elements
+	})
+	chain.keys = true
+
+	return chain
+}
+
+func newCollectionChain[T any, C any, E any](v *validator[T], name string, get func(T) C, elements func(C) []element[E]) *collectionChain[T, C, E] {
+	chain := &collectionChain[T, C, E]{
+		owner:      v,
+		name:       name,
+		get:        get,
+		elements:   elements,
+		rules:      make([]Rule[C], 0),
+		elemRules:  make([]Rule[E], 0),
+		validators: make([]Validator[E], 0),
+	}
+	chain.step = v.AddStepContext(chain.validate)
+	chain.step.describe = chain.describe
+
+	return chain
+}
+
+// BreakOnFailure stops the chain at its first failure and forces the
+// validator to stop processing steps if the chain fails
+func (c *collectionChain[T, C, E]) BreakOnFailure() *collectionChain[T, C, E] {
+	c.step.BreakOnFailure()
+	return c
+}
+
+// When makes the step of the chain run only when predicate returns true for
+// the validated value
+func (c *collectionChain[T, C, E]) When(predicate func(T) bool) *collectionChain[T, C, E] {
+	c.step.When(predicate)
+	return c
+}
+
+// Unless makes the step of the chain run only when predicate returns false
+// for the validated value
+func (c *collectionChain[T, C, E]) Unless(predicate func(T) bool) *collectionChain[T, C, E] {
+	c.step.Unless(predicate)
+	return c
+}
+
+// Named names the step of the chain, so other steps can depend on it
+func (c *collectionChain[T, C, E]) Named(name string) *collectionChain[T, C, E] {
+	c.step.Named(name)
+	return c
+}
+
+// DependsOn makes the step of the chain run only when the named steps passed
+func (c *collectionChain[T, C, E]) DependsOn(names ...string) *collectionChain[T, C, E] {
+	c.step.DependsOn(names...)
+	return c
+}
+
+// WithSeverity reports the errors of the chain with the given severity, e.g.
+// as warnings that do not make the validation fail
+func (c *collectionChain[T, C, E]) WithSeverity(severity Severity) *collectionChain[T, C, E] {
+	c.step.WithSeverity(severity)
+	return c
+}
+
+// SetValidator sets a validator to be run on every element
+func (c *collectionChain[T, C, E]) SetValidator(validator Validator[E]) *collectionChain[T, C, E] {
+	c.validators = append(c.validators, validator)
+	return c
+}
+
+// Check adds rules to be checked on every element
+func (c *collectionChain[T, C, E]) Check(rules ...Rule[E]) *collectionChain[T, C, E] {
+	c.elemRules = append(c.elemRules, rules...)
+	return c
+}
+
+// CheckAll adds rules to be checked on the collection as a whole
+func (c *collectionChain[T, C, E]) CheckAll(rules ...Rule[C]) *collectionChain[T, C, E] {
+	c.rules = append(c.rules, rules...)
+	return c
+}
+
+// MinCount adds a rule that fails when the collection has less than min
+// elements
+func (c *collectionChain[T, C, E]) MinCount(min int) *collectionChain[T, C, E] {
+	msg := fmt.Sprintf("must contain at least %d elements", min)
+	params := map[string]any{"min": min}
+	return c.CheckAll(NewRule(CodeMinCount, msg, params, func(items C) bool {
+		n, _ := lengthOf(items)
+		return n >= min
+	}))
+}
+
+// MaxCount adds a rule that fails when the collection has more than max
+// elements
+func (c *collectionChain[T, C, E]) MaxCount(max int) *collectionChain[T, C, E] {
+	msg := fmt.Sprintf("must contain at most %d elements", max)
+	params := map[string]any{"max": max}
+	return c.CheckAll(NewRule(CodeMaxCount, msg, params, func(items C) bool {
+		n, _ := lengthOf(items)
+		return n <= max
+	}))
+}
+
+func (c *collectionChain[T, C, E]) validate(ctx context.Context, src T) error {
+	items := c.get(src)
+	result := Result{}
+	breakOnFailure := c.step.breakOnFailure || c.owner.breakOnFailure
+
+	for _, rule := range c.rules {
+		if err := rule.Check(items); err != nil {
+			result.addAtPath(c.name, nil, err)
+			if breakOnFailure && c.owner.fails(result) {
+				return result
+			}
+		}
+	}
+
+	for _, elem := range c.elements(items) {
+		path := joinPath(c.name, "["+elem.key+"]")
+
+		for _, rule := range c.elemRules {
+			if err := rule.Check(elem.value); err != nil {
+				result.addAtPath(path, elem.value, err)
+				if breakOnFailure && c.owner.fails(result) {
+					return result
+				}
+			}
+		}
+
+		for _, validator := range c.validators {
+			if res := validateContext(ctx, validator, elem.value); !res.isEmpty() {
+				result.addAtPath(path, nil, res)
+				if (breakOnFailure && c.owner.fails(result)) || result.contextErr != nil {
+					return result
+				}
+			}
+		}
+	}
+
+	if result.isEmpty() {
+		return nil
+	}
+	return result
+}
+
+func (c *collectionChain[T, C, E]) describe() Description {
+	elements := describeRules(c.elemRules)
+	for _, validator := range c.validators {
+		elements.Merge(Describe(validator))
+	}
+
+	d := describeRules(c.rules)
+	if c.keys {
+		d.Keys = &elements
+	} else {
+		d.Elements = &elements
+	}
+	return d.field(c.name, false)
+}
+
+// sortElements sorts the elements of a map by their keys in their natural
+// order, e.g. 2 before 10, see keys.Less
+func sortElements[E any](elements []element[E]) {
+	sort.Slice(elements, func(i, j int) bool {
+		return keys.Less(elements[i].mapKey, elements[j].mapKey)
+	})
+}
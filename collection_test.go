@@ -0,0 +1,222 @@
+package validator_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type orderLine struct {
+	Name     string
+	Quantity int
+}
+
+type order struct {
+	Lines  []orderLine
+	Labels map[string]string
+}
+
+func newOrderLineValidator() validator.Validator[orderLine] {
+	v := validator.New[orderLine]()
+	validator.RuleFor(v, "name", func(l orderLine) string { return l.Name }).
+		NotEmpty()
+	validator.RuleFor(v, "quantity", func(l orderLine) int { return l.Quantity }).
+		Must(func(q int) bool { return q > 0 }, "must be positive")
+	return v
+}
+
+func Test_ForEach_WhenAllElementsAreValid_ShouldReturnSuccess(t *testing.T) {
+	// Arrange
+	req := order{Lines: []orderLine{{Name: "a", Quantity: 1}, {Name: "b", Quantity: 2}}}
+
+	v := validator.New[order]()
+	validator.ForEach(v, "lines", func(o order) []orderLine { return o.Lines }).
+		SetValidator(newOrderLineValidator())
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	assert.True(t, result.IsSuccess())
+}
+
+func Test_ForEach_WhenElementsFail_ShouldPrefixFailuresWithIndex(t *testing.T) {
+	// Arrange
+	req := order{Lines: []orderLine{{Name: "a", Quantity: 1}, {Name: "", Quantity: 2}, {Name: "c", Quantity: 0}}}
+
+	v := validator.New[order]()
+	validator.ForEach(v, "lines", func(o order) []orderLine { return o.Lines }).
+		SetValidator(newOrderLineValidator())
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	failures := result.Failures()
+	assert.Len(t, failures, 2)
+	assert.Equal(t, "lines[1].name", failures[0].Path)
+	assert.Equal(t, validator.CodeNotEmpty, failures[0].Code)
+	assert.Equal(t, "lines[2].quantity", failures[1].Path)
+	assert.Equal(t, "lines[2].quantity: must be positive", failures[1].Error())
+}
+
+func Test_ForEach_WhenFieldIsAnArray_ShouldCheckItAsASlice(t *testing.T) {
+	// Arrange
+	type shipment struct {
+		Lines [3]orderLine
+	}
+	req := shipment{Lines: [3]orderLine{{Name: "a", Quantity: 1}, {Name: "", Quantity: 1}, {Name: "c", Quantity: 0}}}
+
+	v := validator.New[shipment]()
+	validator.ForEach(v, "lines", func(s shipment) []orderLine { return s.Lines[:] }).
+		SetValidator(newOrderLineValidator())
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	assert.Equal(t, []string{"lines[1].name: must not be empty", "lines[2].quantity: must be positive"}, result.GetFailureMessages())
+}
+
+func Test_ForEach_WhenElementRulesFail_ShouldUseIndexAsPath(t *testing.T) {
+	// Arrange
+	req := order{Lines: []orderLine{{Name: "a"}, {Name: ""}}}
+
+	v := validator.New[order]()
+	validator.ForEach(v, "names", func(o order) []string {
+		names := make([]string, len(o.Lines))
+		for i, l := range o.Lines {
+			names[i] = l.Name
+		}
+		return names
+	}).Check(validator.RuleFunc[string](func(s string) error {
+		if s == "" {
+			return errors.New("must not be empty")
+		}
+		return nil
+	}))
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	failures := result.Failures()
+	assert.Len(t, failures, 1)
+	assert.Equal(t, "names[1]", failures[0].Path)
+	assert.Equal(t, "", failures[0].Value)
+}
+
+func Test_ForEach_WhenCountIsOutOfBounds_ShouldFailOnCollectionPath(t *testing.T) {
+	// Arrange
+	req := order{}
+
+	v := validator.New[order]()
+	validator.ForEach(v, "lines", func(o order) []orderLine { return o.Lines }).
+		MinCount(1).
+		MaxCount(3)
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	failures := result.Failures()
+	assert.Len(t, failures, 1)
+	assert.Equal(t, "lines", failures[0].Path)
+	assert.Equal(t, validator.CodeMinCount, failures[0].Code)
+}
+
+func Test_ForEach_WhenBreakOnFailureIsSet_ShouldStopAtFirstFailure(t *testing.T) {
+	// Arrange
+	req := order{Lines: []orderLine{{Name: ""}, {Name: ""}}}
+
+	v := validator.New[order]()
+	validator.ForEach(v, "lines", func(o order) []orderLine { return o.Lines }).
+		SetValidator(newOrderLineValidator()).
+		BreakOnFailure()
+	v.AddStep(func(o order) error { return errors.New("Error-2") })
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	failures := result.Failures()
+	assert.Len(t, failures, 2)
+	assert.Equal(t, "lines[0].name", failures[0].Path)
+	assert.Equal(t, "lines[0].quantity", failures[1].Path)
+}
+
+func Test_ForEachValue_WhenValuesFail_ShouldPrefixFailuresWithKeyInOrder(t *testing.T) {
+	// Arrange
+	req := order{Labels: map[string]string{"zone": "", "env": "", "team": "core"}}
+
+	v := validator.New[order]()
+	validator.ForEachValue(v, "labels", func(o order) map[string]string { return o.Labels }).
+		Check(validator.RuleFunc[string](func(s string) error {
+			if s == "" {
+				return errors.New("must not be empty")
+			}
+			return nil
+		}))
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	failures := result.Failures()
+	assert.Len(t, failures, 2)
+	assert.Equal(t, "labels[env]", failures[0].Path)
+	assert.Equal(t, "labels[zone]", failures[1].Path)
+}
+
+func Test_ForEachValue_WhenKeysAreNumbers_ShouldCheckThemInNumericOrder(t *testing.T) {
+	// Arrange
+	type inventory struct {
+		Stock map[int]int
+	}
+	req := inventory{Stock: map[int]int{10: -1, 2: -1, 1: -1, 3: 4}}
+
+	v := validator.New[inventory]()
+	validator.ForEachValue(v, "stock", func(i inventory) map[int]int { return i.Stock }).
+		Check(validator.RuleFunc[int](func(n int) error {
+			if n < 0 {
+				return errors.New("must not be negative")
+			}
+			return nil
+		}))
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	assert.Equal(t, []string{
+		"stock[1]: must not be negative",
+		"stock[2]: must not be negative",
+		"stock[10]: must not be negative",
+	}, result.GetFailureMessages())
+}
+
+func Test_ForEachKey_WhenKeysFail_ShouldPrefixFailuresWithKey(t *testing.T) {
+	// Arrange
+	req := order{Labels: map[string]string{"Env": "prod", "team": "core"}}
+
+	v := validator.New[order]()
+	validator.ForEachKey(v, "labels", func(o order) map[string]string { return o.Labels }).
+		MaxCount(5).
+		Check(validator.RuleFunc[string](func(s string) error {
+			if s != "team" && s != "env" {
+				return errors.New("unknown label")
+			}
+			return nil
+		}))
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	failures := result.Failures()
+	assert.Len(t, failures, 1)
+	assert.Equal(t, "labels[Env]", failures[0].Path)
+	assert.Equal(t, "Env", failures[0].Value)
+}
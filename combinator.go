@@ -0,0 +1,205 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+)
+
+// Failure codes produced by the combinators
+const (
+	// CodeExactlyOne is used when more than one of the validators of OneOf
+	// pass
+	CodeExactlyOne = "exactly_one"
+	// CodeNegated is used when the validator of Not passes
+	CodeNegated = "negated"
+)
+
+type allOf[T any] struct {
+	validators []Validator[T]
+}
+
+// AllOf returns a validator that passes when all the validators pass,
+// reporting the failures of all of them
+func AllOf[T any](validators ...Validator[T]) Validator[T] {
+	return allOf[T]{validators: validators}
+}
+
+// And returns a validator that passes when both a and b pass
+func And[T any](a, b Validator[T]) Validator[T] {
+	return AllOf(a, b)
+}
+
+func (v allOf[T]) Validate(src T) Result {
+	return v.ValidateContext(context.Background(), src)
+}
+
+func (v allOf[T]) ValidateContext(ctx context.Context, src T) Result {
+	if len(v.validators) == 0 {
+		return noValidators()
+	}
+
+	result := Result{}
+	for _, validator := range v.validators {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			result.contextErr = ctxErr
+			return result
+		}
+
+		result.Merge(validateContext(ctx, validator, src))
+		if result.contextErr != nil {
+			return result
+		}
+	}
+	return result
+}
+
+// Describe merges the descriptions of the validators
+func (v allOf[T]) Describe() Description {
+	d := Description{}
+	for _, validator := range v.validators {
+		d.Merge(Describe(validator))
+	}
+	return d
+}
+
+type anyOf[T any] struct {
+	validators []Validator[T]
+}
+
+// AnyOf returns a validator that passes when at least one of the validators
+// passes, e.g. when either the email or the phone is valid. Validators are
+// run in order until one passes, and the failures of all of them are
+// reported only when none does
+func AnyOf[T any](validators ...Validator[T]) Validator[T] {
+	return anyOf[T]{validators: validators}
+}
+
+// Or returns a validator that passes when a or b pass
+func Or[T any](a, b Validator[T]) Validator[T] {
+	return AnyOf(a, b)
+}
+
+func (v anyOf[T]) Validate(src T) Result {
+	return v.ValidateContext(context.Background(), src)
+}
+
+func (v anyOf[T]) ValidateContext(ctx context.Context, src T) Result {
+	if len(v.validators) == 0 {
+		return noValidators()
+	}
+
+	failures := Result{}
+	for _, validator := range v.validators {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return Result{contextErr: ctxErr}
+		}
+
+		result := validateContext(ctx, validator, src)
+		if result.IsSuccess() {
+			return result
+		}
+		if result.contextErr != nil {
+			return result
+		}
+		failures.Merge(result)
+	}
+	return failures
+}
+
+type oneOf[T any] struct {
+	validators []Validator[T]
+}
+
+// OneOf returns a validator that passes when exactly one of the validators
+// passes. When none does, the failures of all of them are reported, and when
+// several do, a failure with code CodeExactlyOne
+func OneOf[T any](validators ...Validator[T]) Validator[T] {
+	return oneOf[T]{validators: validators}
+}
+
+func (v oneOf[T]) Validate(src T) Result {
+	return v.ValidateContext(context.Background(), src)
+}
+
+func (v oneOf[T]) ValidateContext(ctx context.Context, src T) Result {
+	if len(v.validators) == 0 {
+		return noValidators()
+	}
+
+	failures := Result{}
+	passed := make([]Result, 0, 1)
+	for _, validator := range v.validators {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return Result{contextErr: ctxErr}
+		}
+
+		result := validateContext(ctx, validator, src)
+		switch {
+		case result.contextErr != nil:
+			return result
+		case result.IsSuccess():
+			passed = append(passed, result)
+		default:
+			failures.Merge(result)
+		}
+	}
+
+	switch len(passed) {
+	case 0:
+		return failures
+	case 1:
+		return passed[0]
+	}
+
+	result := Result{}
+	result.AddFailure(Failure{
+		Code:    CodeExactlyOne,
+		Message: fmt.Sprintf("must pass exactly one validation, passes %d", len(passed)),
+		Value:   src,
+		Params:  map[string]any{"passed": len(passed)},
+	})
+	return result
+}
+
+type not[T any] struct {
+	validator Validator[T]
+	message   string
+}
+
+// Not returns a validator that passes when validator does not pass, and
+// otherwise fails with the given message and code CodeNegated
+func Not[T any](validator Validator[T], message string) Validator[T] {
+	return not[T]{validator: validator, message: message}
+}
+
+func (v not[T]) Validate(src T) Result {
+	return v.ValidateContext(context.Background(), src)
+}
+
+func (v not[T]) ValidateContext(ctx context.Context, src T) Result {
+	result := validateContext(ctx, v.validator, src)
+	if result.contextErr != nil {
+		return Result{contextErr: result.contextErr}
+	}
+	if result.IsFailure() {
+		return Result{}
+	}
+
+	negated := Result{}
+	negated.AddFailure(Failure{
+		Code:    CodeNegated,
+		Message: v.message,
+		Value:   src,
+	})
+	return negated
+}
+
+// noValidators returns the result of a combinator with no validators
+func noValidators() Result {
+	result := Result{}
+	result.AddFailure(Failure{
+		Code:    CodeNoSteps,
+		Message: "No validation steps defined",
+	})
+	return result
+}
@@ -0,0 +1,148 @@
+package validator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type contact struct {
+	Email string
+	Phone string
+}
+
+func newEmailValidator() validator.Validator[contact] {
+	v := validator.New[contact]()
+	validator.RuleFor(v, "email", func(c contact) string { return c.Email }).
+		NotEmpty()
+	return v
+}
+
+func newPhoneValidator() validator.Validator[contact] {
+	v := validator.New[contact]()
+	validator.RuleFor(v, "phone", func(c contact) string { return c.Phone }).
+		NotEmpty()
+	return v
+}
+
+func Test_AllOf_WhenValidatorsFail_ShouldReportAllTheFailures(t *testing.T) {
+	// Arrange
+	v := validator.AllOf(newEmailValidator(), newPhoneValidator())
+
+	// Act
+	result := v.Validate(contact{})
+
+	// Assert
+	assert.Equal(t, []string{"email: must not be empty", "phone: must not be empty"}, result.GetFailureMessages())
+	assert.Equal(t, result, validator.And(newEmailValidator(), newPhoneValidator()).Validate(contact{}))
+}
+
+func Test_AnyOf_WhenOneValidatorPasses_ShouldPass(t *testing.T) {
+	tests := []struct {
+		name    string
+		contact contact
+	}{
+		{name: "first", contact: contact{Email: "ann@example.com"}},
+		{name: "last", contact: contact{Phone: "555"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			v := validator.AnyOf(newEmailValidator(), newPhoneValidator())
+
+			// Act
+			result := v.Validate(tt.contact)
+
+			// Assert
+			assert.True(t, result.IsSuccess())
+		})
+	}
+}
+
+func Test_AnyOf_WhenNoValidatorPasses_ShouldReportAllTheFailures(t *testing.T) {
+	// Arrange
+	v := validator.Or(newEmailValidator(), newPhoneValidator())
+
+	// Act
+	result := v.Validate(contact{})
+
+	// Assert
+	assert.Equal(t, []string{"email: must not be empty", "phone: must not be empty"}, result.GetFailureMessages())
+}
+
+func Test_OneOf_WhenSeveralValidatorsPass_ShouldFail(t *testing.T) {
+	tests := []struct {
+		name    string
+		contact contact
+		want    []string
+	}{
+		{name: "one", contact: contact{Email: "ann@example.com"}, want: []string{}},
+		{name: "none", contact: contact{}, want: []string{"email: must not be empty", "phone: must not be empty"}},
+		{name: "both", contact: contact{Email: "ann@example.com", Phone: "555"}, want: []string{"must pass exactly one validation, passes 2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			v := validator.OneOf(newEmailValidator(), newPhoneValidator())
+
+			// Act
+			result := v.Validate(tt.contact)
+
+			// Assert
+			assert.Equal(t, tt.want, result.GetFailureMessages())
+		})
+	}
+}
+
+func Test_Not_WhenValidatorPasses_ShouldFailWithTheMessage(t *testing.T) {
+	// Arrange
+	v := validator.Not(newPhoneValidator(), "phone must not be set")
+
+	// Act
+	passed := v.Validate(contact{})
+	failed := v.Validate(contact{Phone: "555"})
+
+	// Assert
+	assert.True(t, passed.IsSuccess())
+	assert.Equal(t, validator.CodeNegated, failed.Failures()[0].Code)
+	assert.Equal(t, []string{"phone must not be set"}, failed.GetFailureMessages())
+}
+
+func Test_Combinators_WhenUsedAsStep_ShouldComposeWithOtherRules(t *testing.T) {
+	// Arrange
+	v := validator.New[contact]()
+	v.AddValidator(validator.AnyOf(newEmailValidator(), newPhoneValidator()))
+	v.AddValidator(validator.Not(validator.AllOf(newEmailValidator(), newPhoneValidator()), "only one contact is allowed"))
+
+	// Act
+	result := v.Validate(contact{Email: "ann@example.com", Phone: "555"})
+
+	// Assert
+	assert.Equal(t, []string{"only one contact is allowed"}, result.GetFailureMessages())
+}
+
+func Test_Combinators_WhenContextIsCanceled_ShouldReportTheContextError(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	combinators := []validator.Validator[contact]{
+		validator.AllOf(newEmailValidator()),
+		validator.AnyOf(newEmailValidator()),
+		validator.OneOf(newEmailValidator()),
+		validator.Not(newEmailValidator(), "email must be empty"),
+	}
+
+	for _, v := range combinators {
+		// Act
+		result := v.(validator.ContextValidator[contact]).ValidateContext(ctx, contact{})
+
+		// Assert
+		assert.ErrorIs(t, result.ContextErr(), context.Canceled)
+		assert.Empty(t, result.Failures())
+	}
+}
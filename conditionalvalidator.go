@@ -1,24 +1,92 @@
 package validator
 
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultBranch is the branch label of the failures of the default validator
+// of a conditional validator matching all. No condition value nor branch
+// label can take it
+const DefaultBranch = "(default)"
+
 type conditionalValidator[TCond any, TRequest any] struct {
-	validators       map[any]Validator[TRequest]
+	routes           []route[TRequest]
+	keys             map[any]int
 	defaultValidator Validator[TRequest]
-	condition        func(TRequest) TCond
+	conditions       []func(TRequest) []TCond
+	matchAll         bool
+}
+
+// route is a validator run when one of the condition values or its predicate
+// matches the request. Its failures are labeled with the branch label given
+// by the caller, or with its condition value when matching all
+type route[TRequest any] struct {
+	label     string
+	value     string
+	predicate func(TRequest) bool
+	validator Validator[TRequest]
 }
 
 func NewConditional[TCond any, TRequest any]() *conditionalValidator[TCond, TRequest] {
 	return &conditionalValidator[TCond, TRequest]{
-		validators: make(map[any]Validator[TRequest]),
+		keys: make(map[any]int),
 	}
 }
 
+// WithCondition sets the condition whose value selects the validator,
+// replacing the conditions added before
 func (v *conditionalValidator[TCond, TRequest]) WithCondition(condition func(TRequest) TCond) *conditionalValidator[TCond, TRequest] {
-	v.condition = condition
+	v.conditions = nil
+	return v.AddCondition(condition)
+}
+
+// AddCondition adds a condition, so a request can match the validators of
+// the values of several conditions, e.g. its region and its value band
+func (v *conditionalValidator[TCond, TRequest]) AddCondition(condition func(TRequest) TCond) *conditionalValidator[TCond, TRequest] {
+	return v.AddConditionSet(func(req TRequest) []TCond {
+		return []TCond{condition(req)}
+	})
+}
+
+// AddConditionSet adds a condition returning several values, each of them
+// matching its validator
+func (v *conditionalValidator[TCond, TRequest]) AddConditionSet(condition func(TRequest) []TCond) *conditionalValidator[TCond, TRequest] {
+	v.conditions = append(v.conditions, condition)
 	return v
 }
 
+// WithValidator sets the validator run when a condition returns the given
+// value. Setting a value twice replaces its validator, keeping its order.
+// When matching all, the failures of the validator are labeled with the value
+// as branch.
+// WithValidator panics if the value prints as DefaultBranch
 func (v *conditionalValidator[TCond, TRequest]) WithValidator(condition TCond, validator Validator[TRequest]) *conditionalValidator[TCond, TRequest] {
-	v.validators[condition] = validator
+	if i, ok := v.keys[condition]; ok {
+		v.routes[i].validator = validator
+		return v
+	}
+
+	value := fmt.Sprint(condition)
+	mustNotBeDefaultBranch(value)
+	v.keys[condition] = len(v.routes)
+	v.routes = append(v.routes, route[TRequest]{value: value, validator: validator})
+	return v
+}
+
+// When adds a validator run when predicate returns true for the request,
+// e.g. for ranges of values. Predicates and condition values are matched in
+// the order they were added
+func (v *conditionalValidator[TCond, TRequest]) When(predicate func(TRequest) bool, validator Validator[TRequest]) *conditionalValidator[TCond, TRequest] {
+	return v.Branch("", predicate, validator)
+}
+
+// Branch adds a validator run when predicate returns true for the request,
+// like When, whose failures are labeled with the given branch label.
+// Branch panics if label is DefaultBranch
+func (v *conditionalValidator[TCond, TRequest]) Branch(label string, predicate func(TRequest) bool, validator Validator[TRequest]) *conditionalValidator[TCond, TRequest] {
+	mustNotBeDefaultBranch(label)
+	v.routes = append(v.routes, route[TRequest]{label: label, predicate: predicate, validator: validator})
 	return v
 }
 
@@ -27,18 +95,118 @@ func (v *conditionalValidator[TCond, TRequest]) WithDefaultValidator(validator V
 	return v
 }
 
+// MatchFirst runs only the validator of the first matching condition value
+// or predicate. It is the default mode
+func (v *conditionalValidator[TCond, TRequest]) MatchFirst() *conditionalValidator[TCond, TRequest] {
+	v.matchAll = false
+	return v
+}
+
+// MatchAll runs the validators of all the matching condition values and
+// predicates, in the order they were added, merging their results
+func (v *conditionalValidator[TCond, TRequest]) MatchAll() *conditionalValidator[TCond, TRequest] {
+	v.matchAll = true
+	return v
+}
+
 func (v *conditionalValidator[TCond, TRequest]) Validate(req TRequest) Result {
-	condition := v.condition(req)
-	validator, ok := v.validators[condition]
-	if !ok {
+	return v.ValidateContext(context.Background(), req)
+}
+
+// ValidateContext evaluates the conditions and the predicates and runs the
+// matching validators, passing ctx to them when they are ContextValidators.
+// When none matches, the default validator is run.
+//
+// The failures of the validators added with Branch are labeled with its
+// label, see Failure.Branch. When matching all, the failures of the other
+// validators are labeled too, with their condition value or DefaultBranch, so
+// the branch that produced them can be told apart
+func (v *conditionalValidator[TCond, TRequest]) ValidateContext(ctx context.Context, req TRequest) Result {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return Result{contextErr: ctxErr}
+	}
+
+	values := make([]TCond, 0, len(v.conditions))
+	for _, condition := range v.conditions {
+		values = append(values, condition(req)...)
+	}
+
+	routes := v.match(req, values)
+	if len(routes) == 0 {
 		if v.defaultValidator != nil {
-			return v.defaultValidator.Validate(req)
+			result := validateContext(ctx, v.defaultValidator, req)
+			if v.matchAll {
+				result = result.withBranch(DefaultBranch)
+			}
+			return result
+		}
+
+		var value any
+		if len(values) == 1 {
+			value = values[0]
+		} else if len(values) > 1 {
+			value = values
 		}
 
 		result := Result{}
-		result.AddFailureMessage("No validator found for condition")
+		result.AddFailure(Failure{
+			Code:    CodeNoValidator,
+			Message: "No validator found for condition",
+			Value:   value,
+		})
 		return result
 	}
 
-	return validator.Validate(req)
+	result := Result{}
+	for _, r := range routes {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			result.contextErr = ctxErr
+			return result
+		}
+
+		result.Merge(validateContext(ctx, r.validator, req).withBranch(v.label(r)))
+	}
+	return result
+}
+
+// label returns the branch label of the failures of r
+func (v *conditionalValidator[TCond, TRequest]) label(r route[TRequest]) string {
+	if r.label == "" && v.matchAll {
+		return r.value
+	}
+	return r.label
+}
+
+// match returns the routes matching req, whose condition values are values.
+// Only the first one is returned unless matching all
+func (v *conditionalValidator[TCond, TRequest]) match(req TRequest, values []TCond) []route[TRequest] {
+	keyed := make(map[int]bool, len(values))
+	for _, value := range values {
+		if i, ok := v.keys[value]; ok {
+			keyed[i] = true
+		}
+	}
+
+	routes := make([]route[TRequest], 0, 1)
+	for i, r := range v.routes {
+		if r.predicate != nil {
+			if !r.predicate(req) {
+				continue
+			}
+		} else if !keyed[i] {
+			continue
+		}
+
+		routes = append(routes, r)
+		if !v.matchAll {
+			break
+		}
+	}
+	return routes
+}
+
+func mustNotBeDefaultBranch(label string) {
+	if label == DefaultBranch {
+		panic(fmt.Sprintf("validator: branch label %q is reserved for the default validator", label))
+	}
 }
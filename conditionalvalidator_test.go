@@ -85,3 +85,239 @@ func Test_component_Description(t *testing.T) {
 		})
 	}
 }
+
+func Test_Conditional_WhenNoValidatorFound_ShouldReturnStructuredFailure(t *testing.T) {
+	// Arrange
+	condVal := NewConditional[int, testRequest]()
+	condVal.WithCondition(func(req testRequest) int {
+		return req.value
+	})
+
+	// Act
+	result := condVal.Validate(testRequest{value: 7})
+
+	// Assert
+	failures := result.Failures()
+	assert.Len(t, failures, 1)
+	assert.Equal(t, CodeNoValidator, failures[0].Code)
+	assert.Equal(t, 7, failures[0].Value)
+}
+
+// failingValidator returns a validator failing with msg
+func failingValidator(msg string) Validator[testRequest] {
+	v := New[testRequest]()
+	v.AddStep(func(testRequest) error { return errors.New(msg) })
+	return v
+}
+
+func Test_Conditional_WhenPredicatesMatch_ShouldRunTheFirstMatchingValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   int
+		wantMsg string
+	}{
+		{name: "condition value before predicates", value: 1, wantMsg: "one"},
+		{name: "first matching predicate", value: 20000, wantMsg: "enhanced"},
+		{name: "later predicate", value: 50, wantMsg: "standard"},
+		{name: "no match", value: -1, wantMsg: "default"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			condVal := NewConditional[int, testRequest]().
+				WithCondition(func(req testRequest) int { return req.value }).
+				WithValidator(1, failingValidator("one")).
+				When(func(req testRequest) bool { return req.value > 10000 }, failingValidator("enhanced")).
+				When(func(req testRequest) bool { return req.value > 0 }, failingValidator("standard")).
+				WithDefaultValidator(failingValidator("default"))
+
+			// Act
+			result := condVal.Validate(testRequest{value: test.value})
+
+			// Assert
+			assert.Equal(t, []string{test.wantMsg}, result.GetFailureMessages())
+		})
+	}
+}
+
+func Test_Conditional_WhenMatchingAll_ShouldRunEveryMatchingValidatorInOrder(t *testing.T) {
+	// Arrange
+	condVal := NewConditional[int, testRequest]().
+		MatchAll().
+		When(func(req testRequest) bool { return req.value > 10000 }, failingValidator("enhanced")).
+		WithCondition(func(req testRequest) int { return req.value % 2 }).
+		WithValidator(0, failingValidator("even")).
+		When(func(req testRequest) bool { return req.value > 0 }, failingValidator("standard")).
+		When(func(req testRequest) bool { return req.value < 0 }, failingValidator("refund"))
+
+	// Act
+	result := condVal.Validate(testRequest{value: 20000})
+
+	// Assert
+	assert.Equal(t, []string{"enhanced", "even", "standard"}, result.GetFailureMessages())
+}
+
+func Test_Conditional_WhenValueIsSetTwice_ShouldReplaceItsValidatorInPlace(t *testing.T) {
+	// Arrange
+	condVal := NewConditional[int, testRequest]().
+		MatchAll().
+		WithCondition(func(req testRequest) int { return req.value }).
+		WithValidator(5, failingValidator("first")).
+		When(func(req testRequest) bool { return true }, failingValidator("always")).
+		WithValidator(5, failingValidator("replaced"))
+
+	// Act
+	result := condVal.Validate(testRequest{value: 5})
+
+	// Assert
+	assert.Equal(t, []string{"replaced", "always"}, result.GetFailureMessages())
+}
+
+func Test_Conditional_WhenOnlyPredicatesAreSet_ShouldNotNeedACondition(t *testing.T) {
+	// Arrange
+	condVal := NewConditional[int, testRequest]().
+		WithValidator(0, failingValidator("zero")).
+		When(func(req testRequest) bool { return req.value > 0 }, failingValidator("positive"))
+
+	// Act
+	result := condVal.Validate(testRequest{value: 0})
+
+	// Assert
+	assert.Equal(t, CodeNoValidator, result.Failures()[0].Code)
+	assert.Nil(t, result.Failures()[0].Value)
+}
+
+type testOrder struct {
+	country string
+	amount  int
+	tags    []string
+}
+
+// failingOrderValidator returns a validator failing with msg
+func failingOrderValidator(msg string) Validator[testOrder] {
+	v := New[testOrder]()
+	v.AddStep(func(testOrder) error { return errors.New(msg) })
+	return v
+}
+
+func branches(result Result) []string {
+	s := make([]string, 0)
+	for _, f := range result.Failures() {
+		s = append(s, f.Branch+": "+f.Message)
+	}
+	return s
+}
+
+func Test_Conditional_WhenSeveralConditionsMatch_ShouldRunAllTheirValidatorsLabeled(t *testing.T) {
+	// Arrange
+	condVal := NewConditional[string, testOrder]().
+		MatchAll().
+		AddCondition(func(o testOrder) string {
+			if o.country != "ES" {
+				return "international"
+			}
+			return "domestic"
+		}).
+		AddCondition(func(o testOrder) string {
+			if o.amount > 10000 {
+				return "high_value"
+			}
+			return "standard"
+		}).
+		WithValidator("international", failingOrderValidator("customs data is missing")).
+		WithValidator("high_value", failingOrderValidator("approval is missing")).
+		WithValidator("standard", failingOrderValidator("not reached"))
+
+	// Act
+	result := condVal.Validate(testOrder{country: "FR", amount: 20000})
+
+	// Assert
+	assert.Equal(t, []string{
+		"international: customs data is missing",
+		"high_value: approval is missing",
+	}, branches(result))
+}
+
+func Test_Conditional_WhenConditionReturnsASetOfValues_ShouldMatchEachOfThem(t *testing.T) {
+	// Arrange
+	condVal := NewConditional[string, testOrder]().
+		MatchAll().
+		AddConditionSet(func(o testOrder) []string { return o.tags }).
+		WithValidator("gift", failingOrderValidator("gift message is missing")).
+		WithValidator("fragile", failingOrderValidator("packaging is missing")).
+		Branch("large", func(o testOrder) bool { return o.amount > 100 }, failingOrderValidator("carrier is missing"))
+
+	// Act
+	result := condVal.Validate(testOrder{amount: 500, tags: []string{"fragile", "gift", "unknown"}})
+
+	// Assert
+	assert.Equal(t, []string{
+		"gift: gift message is missing",
+		"fragile: packaging is missing",
+		"large: carrier is missing",
+	}, branches(result))
+}
+
+func Test_Conditional_WhenNoValueMatches_ShouldReportAllTheValues(t *testing.T) {
+	// Arrange
+	condVal := NewConditional[string, testOrder]().
+		AddConditionSet(func(o testOrder) []string { return o.tags }).
+		WithValidator("gift", failingOrderValidator("gift message is missing"))
+
+	// Act
+	result := condVal.Validate(testOrder{tags: []string{"a", "b"}})
+
+	// Assert
+	assert.Equal(t, CodeNoValidator, result.Failures()[0].Code)
+	assert.Equal(t, []string{"a", "b"}, result.Failures()[0].Value)
+}
+
+func Test_Conditional_WhenNested_ShouldJoinTheBranchLabels(t *testing.T) {
+	// Arrange
+	inner := NewConditional[string, testOrder]().
+		MatchAll().
+		WithCondition(func(o testOrder) string { return o.country }).
+		WithDefaultValidator(failingOrderValidator("country is not supported"))
+	outer := NewConditional[string, testOrder]().
+		Branch("international", func(o testOrder) bool { return o.country != "ES" }, inner)
+
+	// Act
+	result := outer.Validate(testOrder{country: "XX"})
+
+	// Assert
+	assert.Equal(t, []string{"international/" + DefaultBranch + ": country is not supported"}, branches(result))
+	assert.Equal(t, "international/(default)", result.Problem().Errors[0].Branch)
+}
+
+func Test_Conditional_WhenMatchingFirst_ShouldNotLabelTheConditionValuesNorTheDefault(t *testing.T) {
+	// Arrange
+	condVal := NewConditional[string, testOrder]().
+		WithCondition(func(o testOrder) string { return o.country }).
+		WithValidator("FR", failingOrderValidator("customs data is missing")).
+		WithDefaultValidator(failingOrderValidator("country is not supported"))
+
+	// Act
+	keyed := condVal.Validate(testOrder{country: "FR"})
+	fallback := condVal.Validate(testOrder{country: "XX"})
+
+	// Assert
+	assert.Equal(t, []string{": customs data is missing"}, branches(keyed))
+	assert.Equal(t, []string{": country is not supported"}, branches(fallback))
+	assert.Empty(t, keyed.Problem().Errors[0].Branch)
+}
+
+func Test_Conditional_WhenLabelIsTheDefaultBranch_ShouldPanic(t *testing.T) {
+	// Arrange
+	condVal := NewConditional[string, testOrder]()
+	validator := failingOrderValidator("not reached")
+
+	// Act
+	keyed := func() { condVal.WithValidator(DefaultBranch, validator) }
+	labeled := func() { condVal.Branch(DefaultBranch, func(testOrder) bool { return true }, validator) }
+
+	// Assert
+	want := `validator: branch label "(default)" is reserved for the default validator`
+	assert.PanicsWithValue(t, want, keyed)
+	assert.PanicsWithValue(t, want, labeled)
+}
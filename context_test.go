@@ -0,0 +1,129 @@
+package validator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type ctxKey struct{}
+
+func Test_ValidateContext_ShouldPassContextToSteps(t *testing.T) {
+	// Arrange
+	ctx := context.WithValue(context.Background(), ctxKey{}, "tenant-1")
+	req := dummyType{}
+
+	var got any
+	v := validator.New[dummyType]()
+	v.AddStepContext(func(ctx context.Context, src dummyType) error {
+		got = ctx.Value(ctxKey{})
+		return nil
+	})
+
+	// Act
+	result := v.ValidateContext(ctx, req)
+
+	// Assert
+	assert.True(t, result.IsSuccess())
+	assert.Equal(t, "tenant-1", got)
+}
+
+func Test_ValidateContext_WhenContextIsCanceled_ShouldNotRunStepsAndReportContextError(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := dummyType{}
+
+	ran := false
+	v := validator.New[dummyType]()
+	v.AddStep(func(src dummyType) error {
+		ran = true
+		return nil
+	})
+
+	// Act
+	result := v.ValidateContext(ctx, req)
+
+	// Assert
+	assert.False(t, ran)
+	assert.True(t, result.IsFailure())
+	assert.Len(t, result.Failures(), 0)
+	assert.True(t, errors.Is(result.ContextErr(), context.Canceled))
+}
+
+func Test_ValidateContext_WhenContextIsCanceledByAStep_ShouldStopRemainingSteps(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req := dummyType{}
+
+	step1 := func(ctx context.Context, src dummyType) error { return errors.New("Error-1") }
+	step2 := func(ctx context.Context, src dummyType) error {
+		cancel()
+		return ctx.Err()
+	}
+	step3 := func(ctx context.Context, src dummyType) error { return errors.New("Error-3") }
+
+	v := validator.New[dummyType]()
+	v.AddStepContext(step1, step2, step3)
+
+	// Act
+	result := v.ValidateContext(ctx, req)
+
+	// Assert
+	failures := result.GetFailureMessages()
+	assert.Equal(t, []string{"Error-1"}, failures)
+	assert.True(t, errors.Is(result.ContextErr(), context.Canceled))
+	assert.Equal(t, "Error-1;context canceled", result.Error())
+}
+
+func Test_ValidateContext_WhenValidatorIsNested_ShouldPropagateContext(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req := dummyType{}
+
+	inner := validator.New[dummyType]()
+	inner.AddStepContext(func(ctx context.Context, src dummyType) error {
+		cancel()
+		return nil
+	})
+	inner.AddStep(func(src dummyType) error { return errors.New("inner-error") })
+
+	outer := validator.New[dummyType]()
+	outer.AddValidator(inner)
+	outer.AddStep(func(src dummyType) error { return errors.New("outer-error") })
+
+	// Act
+	result := outer.ValidateContext(ctx, req)
+
+	// Assert
+	assert.Len(t, result.Failures(), 0)
+	assert.True(t, errors.Is(result.ContextErr(), context.Canceled))
+}
+
+func Test_ValidateContext_WhenConditional_ShouldPassContextToSelectedValidator(t *testing.T) {
+	// Arrange
+	ctx := context.WithValue(context.Background(), ctxKey{}, "tenant-1")
+
+	var got any
+	v1 := validator.New[int]()
+	v1.AddStepContext(func(ctx context.Context, src int) error {
+		got = ctx.Value(ctxKey{})
+		return nil
+	})
+
+	cond := validator.NewConditional[int, int]().
+		WithCondition(func(req int) int { return req }).
+		WithValidator(1, v1)
+
+	// Act
+	result := cond.ValidateContext(ctx, 1)
+
+	// Assert
+	assert.True(t, result.IsSuccess())
+	assert.Equal(t, "tenant-1", got)
+}
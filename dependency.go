@@ -0,0 +1,198 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SkippedStep records a step that was not run because a step it depends on
+// failed, see DependsOn
+type SkippedStep struct {
+	// Name is the name of the skipped step, empty when it has no name
+	Name string
+	// Failed lists the dependencies of the step that failed or were skipped
+	Failed []string
+}
+
+// Named names the step, so other steps can depend on it with DependsOn.
+// Named panics if name is empty, if another step of the validator has the
+// same name, or if the dependencies of the steps would form a cycle, keeping
+// the previous name of the step
+func (v *validationStep[T]) Named(name string) *validationStep[T] {
+	if name == "" {
+		panic("validator: step name must not be empty")
+	}
+	if step, ok := v.owner.steps[name]; ok && step != v {
+		panic(fmt.Sprintf("validator: step %q is already defined", name))
+	}
+
+	previous := v.name
+	v.rename(previous, name)
+	if cycle := v.owner.cycle(); cycle != "" {
+		v.rename(name, previous)
+		panic(cycle)
+	}
+	return v
+}
+
+// rename moves the step from the name from to the name to
+func (v *validationStep[T]) rename(from, to string) {
+	delete(v.owner.steps, from)
+	v.name = to
+	if to != "" {
+		v.owner.steps[to] = v
+	}
+}
+
+// DependsOn makes the step run only when the named steps passed, e.g. to
+// check that an email domain is not blacklisted only when the email format
+// is valid. When a dependency fails, or is skipped itself, the step is
+// skipped and recorded in Result.Skipped, while the unrelated steps still
+// run. Steps not run because of their When conditions count as passed.
+//
+// Dependencies may be added after the step, and are run before it. DependsOn
+// panics if the dependencies of the steps would form a cycle, without adding
+// them. A dependency that is not the name of a step is reported by Check, and
+// fails the step with code CodeUndefinedDependency when validating
+func (v *validationStep[T]) DependsOn(names ...string) *validationStep[T] {
+	n := len(v.dependsOn)
+	v.dependsOn = append(v.dependsOn, names...)
+	if cycle := v.owner.cycle(); cycle != "" {
+		v.dependsOn = v.dependsOn[:n]
+		panic(cycle)
+	}
+	return v
+}
+
+// cycle returns the panic message describing the first cycle formed by the
+// dependencies of the steps, or an empty string when there is none.
+// Dependencies on names not defined yet are ignored
+func (v *validator[T]) cycle() string {
+	visited := make(map[*validationStep[T]]bool)
+	path := make([]*validationStep[T], 0)
+
+	var visit func(step *validationStep[T]) string
+	visit = func(step *validationStep[T]) string {
+		for i, s := range path {
+			if s == step {
+				names := make([]string, 0, len(path)-i+1)
+				for _, s := range path[i:] {
+					names = append(names, s.name)
+				}
+				names = append(names, step.name)
+				return fmt.Sprintf("validator: steps depend on each other: %s", strings.Join(names, " -> "))
+			}
+		}
+		if visited[step] {
+			return ""
+		}
+
+		path = append(path, step)
+		for _, name := range step.dependsOn {
+			if dependency, ok := v.steps[name]; ok {
+				if cycle := visit(dependency); cycle != "" {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		visited[step] = true
+		return ""
+	}
+
+	for _, step := range v.validators {
+		if cycle := visit(step); cycle != "" {
+			return cycle
+		}
+	}
+	return ""
+}
+
+// Check returns an error for every step depending on a step that is not
+// defined, so a validator can be checked once it is built, before validating
+func (v validator[T]) Check() error {
+	var errs []error
+	for _, step := range v.validators {
+		for _, name := range step.dependsOn {
+			if _, ok := v.steps[name]; !ok {
+				errs = append(errs, fmt.Errorf("validator: step %q depends on undefined step %q", step.name, name))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// MustCheck returns the validator, and panics if Check returns an error
+func (v *validator[T]) MustCheck() *validator[T] {
+	if err := v.Check(); err != nil {
+		panic(err.Error())
+	}
+	return v
+}
+
+// order returns the steps in the order they are run: the order they were
+// added, moving the dependencies of every step before it
+func (v validator[T]) order() []*validationStep[T] {
+	dependent := false
+	for _, step := range v.validators {
+		dependent = dependent || len(step.dependsOn) > 0
+	}
+	if !dependent {
+		return v.validators
+	}
+
+	ordered := make([]*validationStep[T], 0, len(v.validators))
+	added := make(map[*validationStep[T]]bool, len(v.validators))
+
+	var add func(step *validationStep[T])
+	add = func(step *validationStep[T]) {
+		if added[step] {
+			return
+		}
+		added[step] = true
+
+		for _, name := range step.dependsOn {
+			if dependency, ok := v.steps[name]; ok {
+				add(dependency)
+			}
+		}
+		ordered = append(ordered, step)
+	}
+
+	for _, step := range v.validators {
+		add(step)
+	}
+	return ordered
+}
+
+// blocked returns the result of step when it is not run: a failure when it
+// depends on a step that is not defined, or its skip when any of its
+// dependencies failed
+func (v validator[T]) blocked(step *validationStep[T], failed map[*validationStep[T]]bool) (Result, bool) {
+	result := Result{}
+	var names []string
+	for _, name := range step.dependsOn {
+		dependency, ok := v.steps[name]
+		if !ok {
+			result.AddFailure(Failure{
+				Code:    CodeUndefinedDependency,
+				Message: fmt.Sprintf("depends on undefined step %s", name),
+				Params:  map[string]any{"step": step.name, "dependency": name},
+			})
+			continue
+		}
+		if failed[dependency] {
+			names = append(names, name)
+		}
+	}
+
+	if result.IsFailure() {
+		return result, true
+	}
+	if len(names) == 0 {
+		return Result{}, false
+	}
+	result.skipped = append(result.skipped, SkippedStep{Name: step.name, Failed: names})
+	return result, true
+}
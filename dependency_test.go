@@ -0,0 +1,251 @@
+package validator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type signup struct {
+	Email    string
+	Nickname string
+}
+
+func isEmail(s string) bool     { return strings.Contains(s, "@") }
+func notBlocked(s string) bool  { return !strings.HasSuffix(s, "@spam.example") }
+func notReserved(s string) bool { return s != "admin" }
+
+func Test_DependsOn_WhenDependencyFails_ShouldSkipTheStepAndRecordIt(t *testing.T) {
+	// Arrange
+	v := validator.New[signup]()
+	validator.RuleFor(v, "email", func(s signup) string { return s.Email }).
+		Named("email-format").
+		Must(isEmail, "must be an email")
+	validator.RuleFor(v, "email", func(s signup) string { return s.Email }).
+		Named("email-domain").
+		DependsOn("email-format").
+		Must(notBlocked, "must not be a blocked domain")
+	validator.RuleFor(v, "nickname", func(s signup) string { return s.Nickname }).
+		Must(notReserved, "must not be reserved")
+
+	// Act
+	result := v.Validate(signup{Email: "ann", Nickname: "admin"})
+
+	// Assert
+	assert.Equal(t, []string{"email: must be an email", "nickname: must not be reserved"}, result.GetFailureMessages())
+	assert.Equal(t, []validator.SkippedStep{{Name: "email-domain", Failed: []string{"email-format"}}}, result.Skipped())
+}
+
+func Test_DependsOn_WhenDependencyPasses_ShouldRunTheStep(t *testing.T) {
+	// Arrange
+	v := validator.New[signup]()
+	validator.RuleFor(v, "email", func(s signup) string { return s.Email }).
+		Named("email-format").
+		Must(isEmail, "must be an email")
+	validator.RuleFor(v, "email", func(s signup) string { return s.Email }).
+		DependsOn("email-format").
+		Must(notBlocked, "must not be a blocked domain")
+
+	// Act
+	result := v.Validate(signup{Email: "ann@spam.example"})
+
+	// Assert
+	assert.Equal(t, []string{"email: must not be a blocked domain"}, result.GetFailureMessages())
+	assert.Empty(t, result.Skipped())
+}
+
+func Test_DependsOn_WhenDependencyIsSkipped_ShouldSkipTheDependentSteps(t *testing.T) {
+	// Arrange
+	v := validator.New[signup]()
+	validator.RuleFor(v, "email", func(s signup) string { return s.Email }).
+		Named("email-required").
+		NotEmpty()
+	validator.RuleFor(v, "email", func(s signup) string { return s.Email }).
+		Named("email-format").
+		DependsOn("email-required").
+		Must(isEmail, "must be an email")
+	validator.RuleFor(v, "email", func(s signup) string { return s.Email }).
+		Named("email-domain").
+		DependsOn("email-format").
+		Must(notBlocked, "must not be a blocked domain")
+
+	// Act
+	result := v.Validate(signup{})
+
+	// Assert
+	assert.Equal(t, []string{"email: must not be empty"}, result.GetFailureMessages())
+	assert.Equal(t, []validator.SkippedStep{
+		{Name: "email-format", Failed: []string{"email-required"}},
+		{Name: "email-domain", Failed: []string{"email-format"}},
+	}, result.Skipped())
+}
+
+func Test_DependsOn_WhenDependencyIsAddedLater_ShouldRunItFirst(t *testing.T) {
+	// Arrange
+	v := validator.New[signup]()
+	validator.RuleFor(v, "email", func(s signup) string { return s.Email }).
+		DependsOn("email-format").
+		Must(notBlocked, "must not be a blocked domain")
+	validator.RuleFor(v, "email", func(s signup) string { return s.Email }).
+		Named("email-format").
+		Must(isEmail, "must be an email")
+
+	// Act
+	result := v.Validate(signup{Email: "spam.example"})
+
+	// Assert
+	assert.Equal(t, []string{"email: must be an email"}, result.GetFailureMessages())
+	assert.Equal(t, []validator.SkippedStep{{Failed: []string{"email-format"}}}, result.Skipped())
+}
+
+func Test_DependsOn_WhenRunInParallel_ShouldSkipTheStepAndRecordIt(t *testing.T) {
+	// Arrange
+	v := validator.New[signup]().Parallel(4)
+	validator.RuleFor(v, "email", func(s signup) string { return s.Email }).
+		Named("email-format").
+		Must(isEmail, "must be an email")
+	validator.RuleFor(v, "email", func(s signup) string { return s.Email }).
+		Named("email-domain").
+		DependsOn("email-format").
+		Must(notBlocked, "must not be a blocked domain")
+	validator.RuleFor(v, "nickname", func(s signup) string { return s.Nickname }).
+		Must(notReserved, "must not be reserved")
+
+	// Act
+	result := v.Validate(signup{Email: "ann", Nickname: "admin"})
+
+	// Assert
+	assert.Equal(t, []string{"email: must be an email", "nickname: must not be reserved"}, result.GetFailureMessages())
+	assert.Equal(t, []validator.SkippedStep{{Name: "email-domain", Failed: []string{"email-format"}}}, result.Skipped())
+}
+
+func Test_DependsOn_WhenStepsDependOnEachOther_ShouldPanic(t *testing.T) {
+	// Arrange
+	v := validator.New[signup]()
+	validator.RuleFor(v, "email", func(s signup) string { return s.Email }).
+		Named("a").
+		DependsOn("b").
+		NotEmpty()
+	chain := validator.RuleFor(v, "nickname", func(s signup) string { return s.Nickname }).
+		Named("b")
+
+	// Act
+	act := func() { chain.DependsOn("a") }
+
+	// Assert
+	assert.PanicsWithValue(t, "validator: steps depend on each other: a -> b -> a", act)
+}
+
+func Test_DependsOn_WhenCyclePanicIsRecovered_ShouldKeepTheValidatorUsable(t *testing.T) {
+	// Arrange
+	v := validator.New[signup]()
+	validator.RuleFor(v, "email", func(s signup) string { return s.Email }).
+		Named("a").
+		DependsOn("b").
+		Must(isEmail, "must be an email")
+	chain := validator.RuleFor(v, "nickname", func(s signup) string { return s.Nickname }).
+		DependsOn("a")
+
+	// Act
+	renamed := func() { chain.Named("b") }
+	depended := func() { chain.Named("c").DependsOn("c") }
+
+	// Assert
+	assert.PanicsWithValue(t, "validator: steps depend on each other: a -> b -> a", renamed)
+	assert.PanicsWithValue(t, "validator: steps depend on each other: c -> c", depended)
+	validator.RuleFor(v, "email", func(s signup) string { return s.Email }).
+		Named("b").
+		Must(notBlocked, "must not be a blocked domain")
+	result := v.Validate(signup{Email: "ann@spam.example"})
+	assert.NoError(t, v.Check())
+	assert.Equal(t, []string{"email: must not be a blocked domain"}, result.GetFailureMessages())
+	assert.Equal(t, []validator.SkippedStep{
+		{Name: "a", Failed: []string{"b"}},
+		{Name: "c", Failed: []string{"a"}},
+	}, result.Skipped())
+}
+
+func Test_Named_WhenNameIsEmpty_ShouldPanic(t *testing.T) {
+	// Arrange
+	v := validator.New[signup]()
+	chain := validator.RuleFor(v, "email", func(s signup) string { return s.Email })
+
+	// Act
+	act := func() { chain.Named("") }
+
+	// Assert
+	assert.PanicsWithValue(t, "validator: step name must not be empty", act)
+}
+
+func Test_Named_WhenNameIsAlreadyDefined_ShouldPanic(t *testing.T) {
+	// Arrange
+	v := validator.New[signup]()
+	validator.RuleFor(v, "email", func(s signup) string { return s.Email }).
+		Named("email")
+	chain := validator.RuleFor(v, "nickname", func(s signup) string { return s.Nickname })
+
+	// Act
+	act := func() { chain.Named("email") }
+
+	// Assert
+	assert.PanicsWithValue(t, `validator: step "email" is already defined`, act)
+}
+
+func Test_DependsOn_WhenDependencyIsUndefined_ShouldFailTheStep(t *testing.T) {
+	for _, workers := range []int{1, 4} {
+		// Arrange
+		v := validator.New[signup]().Parallel(workers)
+		validator.RuleFor(v, "email", func(s signup) string { return s.Email }).
+			Named("email-domain").
+			DependsOn("email-format").
+			NotEmpty()
+		validator.RuleFor(v, "nickname", func(s signup) string { return s.Nickname }).
+			DependsOn("email-domain").
+			NotEmpty()
+
+		// Act
+		result := v.Validate(signup{})
+
+		// Assert
+		assert.Equal(t, []string{validator.CodeUndefinedDependency}, result.Codes(), "workers: %d", workers)
+		assert.Equal(t, []string{"depends on undefined step email-format"}, result.GetFailureMessages())
+		assert.Equal(t, []validator.SkippedStep{{Failed: []string{"email-domain"}}}, result.Skipped())
+	}
+}
+
+func Test_Check_WhenDependenciesAreUndefined_ShouldReturnError(t *testing.T) {
+	// Arrange
+	v := validator.New[signup]()
+	validator.RuleFor(v, "email", func(s signup) string { return s.Email }).
+		Named("email-domain").
+		DependsOn("email-format", "email-required").
+		NotEmpty()
+
+	// Act
+	err := v.Check()
+
+	// Assert
+	assert.EqualError(t, err, `validator: step "email-domain" depends on undefined step "email-format"`+"\n"+
+		`validator: step "email-domain" depends on undefined step "email-required"`)
+	assert.Panics(t, func() { v.MustCheck() })
+}
+
+func Test_Check_WhenDependenciesAreDefined_ShouldReturnNil(t *testing.T) {
+	// Arrange
+	v := validator.New[signup]()
+	validator.RuleFor(v, "email", func(s signup) string { return s.Email }).
+		DependsOn("email-format").
+		NotEmpty()
+	validator.RuleFor(v, "email", func(s signup) string { return s.Email }).
+		Named("email-format").
+		Must(isEmail, "must be an email")
+
+	// Act
+	err := v.Check()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Same(t, v, v.MustCheck())
+}
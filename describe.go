@@ -0,0 +1,135 @@
+package validator
+
+// Description describes the constraints checked by a validator or a rule, as
+// far as they are known, e.g. to document them as a JSON Schema
+type Description struct {
+	// Rules are the rules checked on the value itself
+	Rules []RuleDescription
+	// Fields are the constraints of the fields of the value, in step order.
+	// The same field may appear more than once
+	Fields []FieldDescription
+	// Elements are the constraints of every element of a slice or array, or
+	// of every value of a map
+	Elements *Description
+	// Keys are the constraints of every key of a map
+	Keys *Description
+	// Opaque is the number of steps and rules whose constraints are not
+	// known, such as the steps added with AddStep or the rules built with
+	// RuleFunc
+	Opaque int
+}
+
+// FieldDescription describes the constraints of a field
+type FieldDescription struct {
+	// Name is the name of the field, as used in the failure paths
+	Name string
+	// Required is true when the field fails if it is nil or empty
+	Required bool
+	Description
+}
+
+// RuleDescription describes a rule by its failure code and parameters
+type RuleDescription struct {
+	Code   string
+	Params map[string]any
+}
+
+// Describer is implemented by the validators and rules able to describe
+// their constraints
+type Describer interface {
+	Describe() Description
+}
+
+// Describe returns the description of v when it is a Describer. Otherwise v
+// is described as opaque
+func Describe(v any) Description {
+	if d, ok := v.(Describer); ok {
+		return d.Describe()
+	}
+	return Description{Opaque: 1}
+}
+
+// Merge adds the constraints of other to the description
+func (d *Description) Merge(other Description) {
+	d.Rules = append(d.Rules, other.Rules...)
+	d.Fields = append(d.Fields, other.Fields...)
+	d.Elements = mergeDescription(d.Elements, other.Elements)
+	d.Keys = mergeDescription(d.Keys, other.Keys)
+	d.Opaque += other.Opaque
+}
+
+// IsEmpty reports whether the description has no constraints at all
+func (d Description) IsEmpty() bool {
+	return len(d.Rules) == 0 && len(d.Fields) == 0 && d.Elements == nil && d.Keys == nil && d.Opaque == 0
+}
+
+func mergeDescription(d *Description, other *Description) *Description {
+	if other == nil {
+		return d
+	}
+	if d == nil {
+		d = &Description{}
+	}
+	d.Merge(*other)
+	return d
+}
+
+// field returns the description of the constraints d applied to the field
+// called name, or d itself when name is empty
+func (d Description) field(name string, required bool) Description {
+	if name == "" && !required {
+		return d
+	}
+	return Description{Fields: []FieldDescription{{Name: name, Required: required, Description: d}}}
+}
+
+type describedRule[F any] struct {
+	Rule[F]
+	description RuleDescription
+}
+
+// DescribeRule returns rule described by code and params, for rules that are
+// not built with NewRule
+func DescribeRule[F any](rule Rule[F], code string, params map[string]any) Rule[F] {
+	return describedRule[F]{
+		Rule:        rule,
+		description: RuleDescription{Code: code, Params: params},
+	}
+}
+
+func (r describedRule[F]) Describe() Description {
+	return Description{Rules: []RuleDescription{r.description}}
+}
+
+func (r codedRule[F]) Describe() Description {
+	return Description{Rules: []RuleDescription{{Code: r.code, Params: r.params}}}
+}
+
+// describeRules returns the description of rules
+func describeRules[F any](rules []Rule[F]) Description {
+	d := Description{}
+	for _, rule := range rules {
+		d.Merge(Describe(rule))
+	}
+	return d
+}
+
+// Describe describes the constraints of all the steps of the validator.
+// Steps added with AddStep or AddStepContext are opaque, unless described
+// with WithDescription. Steps reporting warnings or infos do not constrain
+// the accepted values, so they are not described, and steps run only when a
+// predicate holds are opaque
+func (v validator[T]) Describe() Description {
+	d := Description{}
+	for _, step := range v.validators {
+		if step.severity != SeverityError {
+			continue
+		}
+		if step.describe == nil || len(step.conditions) > 0 {
+			d.Opaque++
+			continue
+		}
+		d.Merge(step.describe())
+	}
+	return d
+}
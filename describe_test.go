@@ -0,0 +1,142 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Describe_WhenRuleChainsAreUsed_ShouldDescribeFieldRules(t *testing.T) {
+	// Arrange
+	v := newAddressValidator()
+
+	// Act
+	d := validator.Describe(v)
+
+	// Assert
+	assert.Equal(t, 0, d.Opaque)
+	assert.Len(t, d.Fields, 2)
+	assert.Equal(t, "city", d.Fields[0].Name)
+	assert.Equal(t, []validator.RuleDescription{{Code: validator.CodeNotEmpty}}, d.Fields[0].Rules)
+	assert.Equal(t, "country", d.Fields[1].Name)
+	assert.Equal(t, []validator.RuleDescription{
+		{Code: validator.CodeNotEmpty},
+		{Code: validator.CodeMaxLength, Params: map[string]any{"max": 2}},
+	}, d.Fields[1].Rules)
+}
+
+func Test_Describe_WhenStepsAreCustom_ShouldCountThemAsOpaque(t *testing.T) {
+	// Arrange
+	v := validator.New[address]()
+	v.AddStep(func(address) error { return nil }, func(address) error { return nil })
+	v.AddStep(func(address) error { return nil }).
+		WithDescription(validator.Description{Rules: []validator.RuleDescription{{Code: "custom"}}})
+	validator.RuleFor(v, "city", func(a address) string { return a.City }).
+		Must(func(s string) bool { return s != "" }, "must be set")
+
+	// Act
+	d := validator.Describe(v)
+
+	// Assert
+	assert.Equal(t, 2, d.Opaque)
+	assert.Equal(t, []validator.RuleDescription{{Code: "custom"}}, d.Rules)
+	assert.Equal(t, []validator.RuleDescription{{Code: validator.CodePredicate}}, d.Fields[0].Rules)
+}
+
+func Test_Describe_WhenValidatorsAreNested_ShouldDescribeNestedFields(t *testing.T) {
+	// Arrange
+	v := validator.New[person]()
+	validator.SetValidator(v, "billing", func(p person) *address { return p.Billing }, newAddressValidator()).
+		Required()
+	validator.SetValidator(v, "shipping", func(p person) *address { return p.Shipping }, validator.New[address]())
+
+	// Act
+	d := validator.Describe(v)
+
+	// Assert
+	assert.Len(t, d.Fields, 2)
+	assert.Equal(t, "billing", d.Fields[0].Name)
+	assert.True(t, d.Fields[0].Required)
+	assert.Len(t, d.Fields[0].Fields, 2)
+	assert.Equal(t, "shipping", d.Fields[1].Name)
+	assert.False(t, d.Fields[1].Required)
+	assert.True(t, d.Fields[1].IsEmpty())
+}
+
+func Test_Describe_WhenCollectionsAreUsed_ShouldDescribeElementsAndKeys(t *testing.T) {
+	// Arrange
+	v := validator.New[order]()
+	validator.ForEach(v, "lines", func(o order) []orderLine { return o.Lines }).
+		MinCount(1).
+		SetValidator(newOrderLineValidator())
+	validator.ForEachKey(v, "labels", func(o order) map[string]string { return o.Labels }).
+		Check(validator.NewRule(validator.CodeMaxLength, "too long", map[string]any{"max": 10}, func(s string) bool {
+			return len(s) <= 10
+		}))
+
+	// Act
+	d := validator.Describe(v)
+
+	// Assert
+	assert.Len(t, d.Fields, 2)
+	lines := d.Fields[0]
+	assert.Equal(t, []validator.RuleDescription{{Code: validator.CodeMinCount, Params: map[string]any{"min": 1}}}, lines.Rules)
+	if assert.NotNil(t, lines.Elements) {
+		assert.Len(t, lines.Elements.Fields, 2)
+	}
+	labels := d.Fields[1]
+	assert.Nil(t, labels.Elements)
+	if assert.NotNil(t, labels.Keys) {
+		assert.Equal(t, validator.CodeMaxLength, labels.Keys.Rules[0].Code)
+	}
+}
+
+func Test_Describe_WhenValueIsNotADescriber_ShouldBeOpaque(t *testing.T) {
+	// Arrange
+	rule := validator.RuleFunc[string](func(string) error { return nil })
+
+	// Act
+	d := validator.Describe(rule)
+	described := validator.Describe(validator.DescribeRule[string](rule, "custom", nil))
+
+	// Assert
+	assert.Equal(t, validator.Description{Opaque: 1}, d)
+	assert.Equal(t, []validator.RuleDescription{{Code: "custom"}}, described.Rules)
+}
+
+func Test_Describe_WhenRulesReportWarnings_ShouldNotDescribeThem(t *testing.T) {
+	// Arrange
+	v := validator.New[customer]()
+	validator.RuleFor(v, "name", func(c customer) string { return c.Name }).
+		Check(validator.Warning(validator.NewRule("strong", "is weak", nil, func(string) bool { return true }))).
+		MaxLen(50)
+	validator.RuleFor(v, "code", func(c customer) string { return c.Code }).
+		WithSeverity(validator.SeverityWarning).
+		NotEmpty()
+
+	// Act
+	d := validator.Describe(v)
+
+	// Assert
+	assert.Equal(t, validator.Description{Fields: []validator.FieldDescription{{
+		Name: "name",
+		Description: validator.Description{Rules: []validator.RuleDescription{
+			{Code: validator.CodeMaxLength, Params: map[string]any{"max": 50}},
+		}},
+	}}}, d)
+}
+
+func Test_Describe_WhenStepIsConditional_ShouldBeOpaque(t *testing.T) {
+	// Arrange
+	v := validator.New[customer]()
+	validator.RuleFor(v, "name", func(c customer) string { return c.Name }).
+		When(func(c customer) bool { return c.Age > 0 }).
+		NotEmpty()
+
+	// Act
+	d := validator.Describe(v)
+
+	// Assert
+	assert.Equal(t, validator.Description{Opaque: 1}, d)
+}
@@ -0,0 +1,82 @@
+package validator
+
+import "strings"
+
+// Failure codes produced by the validators in this package
+const (
+	// CodeNoSteps is used when a validator without steps is asked to validate
+	CodeNoSteps = "no_steps"
+	// CodeNoValidator is used when a conditional validator finds no validator
+	// for the evaluated condition
+	CodeNoValidator = "no_validator"
+	// CodeUndefinedDependency is used when a step depends on a step that is
+	// not defined, see DependsOn
+	CodeUndefinedDependency = "undefined_dependency"
+)
+
+// Failure represents a single structured validation failure
+type Failure struct {
+	// Path identifies the field that failed, e.g. "items[3].name".
+	// It is empty when the failure applies to the whole value
+	Path string
+	// Code is a machine-readable identifier of the failed rule
+	Code string
+	// Message is the human readable description of the failure
+	Message string
+	// Value is the offending value, if known
+	Value any
+	// Params holds the parameters of the failed rule, e.g. {"max": 50}
+	Params map[string]any
+	// Severity is the level of the failure. The zero value is an error
+	Severity Severity
+	// Branch labels the branch of a conditional validator that produced the
+	// failure, e.g. "international", when it was added with a label or the
+	// validator matches all. The labels of nested conditional validators are
+	// joined with "/"
+	Branch string
+	// Key identifies the message of the failure in the message catalogs of a
+	// Translator. When empty, the code is used as key
+	Key string
+	// Err is the original error the failure was created from, if any
+	Err error
+}
+
+// Error implements the error interface. Returns the message prefixed by
+// the path, when the path is not empty
+func (f Failure) Error() string {
+	if f.Path == "" {
+		return f.Message
+	}
+
+	return f.Path + ": " + f.Message
+}
+
+// MessageKey returns the key of the message of the failure in the message
+// catalogs: the key when set, otherwise the code
+func (f Failure) MessageKey() string {
+	if f.Key != "" {
+		return f.Key
+	}
+
+	return f.Code
+}
+
+// Unwrap returns the original error the failure was created from
+func (f Failure) Unwrap() error {
+	return f.Err
+}
+
+// joinPath joins a parent path and a child path. Index segments such as
+// "[3]" are appended without separator
+func joinPath(parent, child string) string {
+	switch {
+	case parent == "":
+		return child
+	case child == "":
+		return parent
+	case strings.HasPrefix(child, "["):
+		return parent + child
+	}
+
+	return parent + "." + child
+}
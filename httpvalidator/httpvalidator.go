@@ -0,0 +1,229 @@
+// Package httpvalidator decodes and validates JSON request bodies in net/http
+// handlers.
+//
+// Middleware and Handler decode the body of every request into a T, run a
+// validator.Validator[T] on it and, when the body cannot be decoded or the
+// value is not valid, respond with an RFC 7807 problem+json document listing
+// the failures, see validator.Result.Problem. Otherwise the decoded value is
+// passed to the next handler, that gets it with FromContext:
+//
+//	mux.Handle("/orders", httpvalidator.Middleware(orderValidator)(
+//		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//			order, _ := httpvalidator.FromContext[Order](r.Context())
+//			...
+//		}),
+//	))
+package httpvalidator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/cgxarrie-go/validator"
+)
+
+// DefaultMaxBodySize is the largest body decoded, in bytes, unless changed
+// with WithMaxBodySize
+const DefaultMaxBodySize = 1 << 20
+
+type config struct {
+	maxBodySize   int64
+	unknownFields bool
+	// problemTypeBase is the base of the problem types, when set with
+	// WithProblemTypeBase
+	problemTypeBase *string
+}
+
+// base returns the base of the problem types, the one set with
+// validator.SetProblemTypeBase unless WithProblemTypeBase is used
+func (c config) base() string {
+	if c.problemTypeBase != nil {
+		return *c.problemTypeBase
+	}
+	return validator.ProblemTypeBase()
+}
+
+// Option configures the decoding of the request bodies and the problems
+// responded
+type Option func(*config)
+
+// WithMaxBodySize sets the largest body decoded, in bytes. Larger bodies are
+// rejected with 413 Request Entity Too Large
+func WithMaxBodySize(n int64) Option {
+	return func(c *config) {
+		c.maxBodySize = n
+	}
+}
+
+// AllowUnknownFields accepts bodies with fields that T does not have, which
+// are rejected by default
+func AllowUnknownFields() Option {
+	return func(c *config) {
+		c.unknownFields = true
+	}
+}
+
+// WithProblemTypeBase sets the base URI of the types of the problems
+// responded, instead of the one set with validator.SetProblemTypeBase, so
+// the handlers of a process can use different bases. An empty base gives the
+// type "about:blank"
+func WithProblemTypeBase(base string) Option {
+	return func(c *config) {
+		c.problemTypeBase = &base
+	}
+}
+
+// contextKey is the key of the decoded value of type T in the request context
+type contextKey[T any] struct{}
+
+// FromContext returns the value decoded and validated by the middleware for
+// T, and false when there is none
+func FromContext[T any](ctx context.Context) (T, bool) {
+	value, ok := ctx.Value(contextKey[T]{}).(T)
+	return value, ok
+}
+
+// Middleware returns a middleware that decodes the body of every request into
+// a T and validates it with v. Valid values are passed to the next handler in
+// the request context, see FromContext. Otherwise the middleware responds
+// with a problem+json document:
+//   - 415 Unsupported Media Type when the content type is not JSON
+//   - 413 Request Entity Too Large when the body is too large
+//   - 400 Bad Request when the body cannot be decoded into a T, or when the
+//     value is not valid, listing the failures in the "errors" member
+//   - 503 Service Unavailable when the validation is interrupted by a
+//     deadline of the request context
+//
+// Nothing is written when the request context is canceled, as the client is
+// gone
+func Middleware[T any](v validator.Validator[T], opts ...Option) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return Handler(v, func(w http.ResponseWriter, r *http.Request, value T) {
+			ctx := context.WithValue(r.Context(), contextKey[T]{}, value)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}, opts...)
+	}
+}
+
+// Handler returns a handler that decodes and validates the body of every
+// request like Middleware, and calls next with the valid values
+func Handler[T any](v validator.Validator[T], next func(w http.ResponseWriter, r *http.Request, value T), opts ...Option) http.Handler {
+	cfg := config{maxBodySize: DefaultMaxBodySize}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value, status, err := decode[T](r, cfg)
+		if err != nil {
+			writeProblem(w, newProblem(cfg.base(), status, err.Error()))
+			return
+		}
+
+		result := validate(r.Context(), v, value)
+		if errors.Is(r.Context().Err(), context.Canceled) {
+			// the client is gone, nobody reads the response
+			return
+		}
+		if result.IsFailure() {
+			writeProblem(w, result.ProblemWithBase(cfg.base()))
+			return
+		}
+
+		next(w, r, value)
+	})
+}
+
+func validate[T any](ctx context.Context, v validator.Validator[T], value T) validator.Result {
+	if cv, ok := v.(validator.ContextValidator[T]); ok {
+		return cv.ValidateContext(ctx, value)
+	}
+	return v.Validate(value)
+}
+
+var errTooLarge = errors.New("request body is too large")
+
+// decode decodes the JSON body of r into a T. When it cannot, it returns the
+// status of the response with the error
+func decode[T any](r *http.Request, cfg config) (T, int, error) {
+	var value T
+
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || !isJSON(mediaType) {
+			return value, http.StatusUnsupportedMediaType, fmt.Errorf("content type %q is not JSON", contentType)
+		}
+	}
+
+	if r.Body == nil || r.Body == http.NoBody {
+		return value, http.StatusBadRequest, errors.New("request body is empty")
+	}
+
+	body := &limitedReader{r: r.Body, n: cfg.maxBodySize}
+	decoder := json.NewDecoder(body)
+	if !cfg.unknownFields {
+		decoder.DisallowUnknownFields()
+	}
+
+	err := decoder.Decode(&value)
+	if err == nil {
+		err = expectEnd(decoder)
+	}
+
+	switch {
+	case err == nil:
+		return value, 0, nil
+	case errors.Is(err, errTooLarge):
+		return value, http.StatusRequestEntityTooLarge, errTooLarge
+	case errors.Is(err, io.EOF):
+		return value, http.StatusBadRequest, errors.New("request body is empty")
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return value, http.StatusBadRequest, errors.New("request body is not valid: unexpected end of JSON input")
+	}
+	return value, http.StatusBadRequest, fmt.Errorf("request body is not valid: %s", strings.TrimPrefix(err.Error(), "json: "))
+}
+
+// expectEnd fails when the body has more data after the decoded value
+func expectEnd(decoder *json.Decoder) error {
+	err := decoder.Decode(&json.RawMessage{})
+	switch {
+	case errors.Is(err, io.EOF):
+		return nil
+	case errors.Is(err, errTooLarge):
+		return err
+	}
+	return errors.New("request body is not valid: it must contain a single JSON value")
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// limitedReader reads up to n bytes from r, and fails with errTooLarge when
+// there are more
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n < 0 {
+		return 0, errTooLarge
+	}
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return 0, errTooLarge
+	}
+	return n, err
+}
@@ -0,0 +1,261 @@
+package httpvalidator_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/cgxarrie-go/validator/httpvalidator"
+	"github.com/cgxarrie-go/validator/rules"
+	"github.com/stretchr/testify/assert"
+)
+
+type order struct {
+	ID       string `json:"id"`
+	Quantity int    `json:"quantity"`
+}
+
+func newOrderValidator() validator.Validator[order] {
+	v := validator.New[order]()
+	validator.RuleFor(v, "id", func(o order) string { return o.ID }).
+		NotEmpty()
+	validator.RuleFor(v, "quantity", func(o order) int { return o.Quantity }).
+		Check(rules.Between(1, 10))
+	return v
+}
+
+// echo responds with the order found in the request context
+var echo = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	o, ok := httpvalidator.FromContext[order](r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(o)
+})
+
+func serve(handler http.Handler, body string, contentType string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func problemOf(t *testing.T, rec *httptest.ResponseRecorder) map[string]any {
+	t.Helper()
+	assert.Equal(t, httpvalidator.ContentType, rec.Header().Get("Content-Type"))
+
+	p := map[string]any{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+	return p
+}
+
+func TestMiddleware_WhenBodyIsValid_ShouldPassTheValueToTheNextHandler(t *testing.T) {
+	// Arrange
+	handler := httpvalidator.Middleware(newOrderValidator())(echo)
+
+	// Act
+	rec := serve(handler, `{"id": "A-1", "quantity": 2}`, "application/json; charset=utf-8")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"id": "A-1", "quantity": 2}`, rec.Body.String())
+}
+
+func TestMiddleware_WhenValueIsInvalid_ShouldRespondWithProblemListingFailures(t *testing.T) {
+	// Arrange
+	handler := httpvalidator.Middleware(newOrderValidator())(echo)
+
+	// Act
+	rec := serve(handler, `{"id": "", "quantity": 20}`, "application/json")
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	p := problemOf(t, rec)
+	assert.Equal(t, "about:blank", p["type"])
+	assert.Equal(t, "Bad Request", p["title"])
+	assert.Equal(t, float64(http.StatusBadRequest), p["status"])
+	assert.Equal(t, []any{
+		map[string]any{"path": "id", "code": validator.CodeNotEmpty, "message": "must not be empty"},
+		map[string]any{"path": "quantity", "code": rules.CodeBetween, "message": "must be between 1 and 10"},
+	}, p["errors"])
+}
+
+func TestMiddleware_WhenBodyCannotBeDecoded_ShouldRespondWithProblem(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		opts        []httpvalidator.Option
+		status      int
+		detail      string
+	}{
+		{name: "empty", body: "", status: http.StatusBadRequest, detail: "request body is empty"},
+		{name: "syntax", body: `{"id": `, status: http.StatusBadRequest, detail: "unexpected end of JSON input"},
+		{name: "wrong type", body: `{"id": 1}`, status: http.StatusBadRequest, detail: "cannot unmarshal number"},
+		{name: "unknown field", body: `{"id": "A", "extra": 1}`, status: http.StatusBadRequest, detail: `unknown field "extra"`},
+		{name: "trailing data", body: `{"id": "A"} {}`, status: http.StatusBadRequest, detail: "single JSON value"},
+		{name: "content type", body: `{}`, contentType: "text/plain", status: http.StatusUnsupportedMediaType, detail: "is not JSON"},
+		{
+			name:   "too large",
+			body:   `{"id": "` + strings.Repeat("a", 100) + `"}`,
+			opts:   []httpvalidator.Option{httpvalidator.WithMaxBodySize(64)},
+			status: http.StatusRequestEntityTooLarge,
+			detail: "request body is too large",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			handler := httpvalidator.Middleware(newOrderValidator(), tt.opts...)(echo)
+
+			// Act
+			rec := serve(handler, tt.body, tt.contentType)
+
+			// Assert
+			assert.Equal(t, tt.status, rec.Code)
+			p := problemOf(t, rec)
+			assert.Equal(t, http.StatusText(tt.status), p["title"])
+			assert.Contains(t, p["detail"], tt.detail)
+			assert.Nil(t, p["errors"])
+		})
+	}
+}
+
+func TestMiddleware_WhenProblemTypeBaseIsSet_ShouldUseItForAllProblems(t *testing.T) {
+	// Arrange
+	handler := httpvalidator.Middleware(newOrderValidator(),
+		httpvalidator.WithMaxBodySize(64),
+		httpvalidator.WithProblemTypeBase("https://errors.example.com/"),
+	)(echo)
+
+	// Act
+	invalid := serve(handler, `{"id": ""}`, "application/json")
+	syntax := serve(handler, `{"id": `, "application/json")
+	tooLarge := serve(handler, `{"id": "`+strings.Repeat("a", 100)+`"}`, "application/json")
+	mediaType := serve(handler, `{}`, "text/plain")
+
+	// Assert
+	assert.Equal(t, "https://errors.example.com/"+validator.ProblemTypeValidation, problemOf(t, invalid)["type"])
+	assert.Equal(t, "https://errors.example.com/"+httpvalidator.ProblemTypeInvalidBody, problemOf(t, syntax)["type"])
+	assert.Equal(t, "https://errors.example.com/"+httpvalidator.ProblemTypeBodyTooLarge, problemOf(t, tooLarge)["type"])
+	assert.Equal(t, "https://errors.example.com/"+httpvalidator.ProblemTypeUnsupportedMediaType, problemOf(t, mediaType)["type"])
+}
+
+func TestMiddleware_WhenProblemTypeBaseIsNotSet_ShouldUseTheDefaultBase(t *testing.T) {
+	// Arrange
+	validator.SetProblemTypeBase("https://errors.example.com/")
+	defer validator.SetProblemTypeBase("")
+	defaulted := httpvalidator.Middleware(newOrderValidator())(echo)
+	blank := httpvalidator.Middleware(newOrderValidator(), httpvalidator.WithProblemTypeBase(""))(echo)
+
+	// Act
+	defaultedRec := serve(defaulted, `{"id": ""}`, "application/json")
+	blankRec := serve(blank, `{"id": ""}`, "application/json")
+
+	// Assert
+	assert.Equal(t, "https://errors.example.com/"+validator.ProblemTypeValidation, problemOf(t, defaultedRec)["type"])
+	assert.Equal(t, "about:blank", problemOf(t, blankRec)["type"])
+}
+
+func TestMiddleware_WhenUnknownFieldsAreAllowed_ShouldIgnoreThem(t *testing.T) {
+	// Arrange
+	handler := httpvalidator.Middleware(newOrderValidator(), httpvalidator.AllowUnknownFields())(echo)
+
+	// Act
+	rec := serve(handler, `{"id": "A-1", "quantity": 1, "extra": true}`, "")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestMiddleware_WhenBodyIsExactlyTheMaxSize_ShouldDecodeIt(t *testing.T) {
+	// Arrange
+	body := `{"id": "A-1", "quantity": 1}`
+	handler := httpvalidator.Middleware(newOrderValidator(), httpvalidator.WithMaxBodySize(int64(len(body))))(echo)
+
+	// Act
+	rec := serve(handler, body, "application/json")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestHandler_WhenBodyIsValid_ShouldCallNextWithTheValue(t *testing.T) {
+	// Arrange
+	var got order
+	handler := httpvalidator.Handler(newOrderValidator(), func(w http.ResponseWriter, r *http.Request, o order) {
+		got = o
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	// Act
+	rec := serve(handler, `{"id": "A-1", "quantity": 3}`, "application/vnd.orders+json")
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, order{ID: "A-1", Quantity: 3}, got)
+}
+
+func TestHandler_WhenRequestContextIsCanceled_ShouldNotCallNext(t *testing.T) {
+	// Arrange
+	called := false
+	handler := httpvalidator.Handler(newOrderValidator(), func(http.ResponseWriter, *http.Request, order) {
+		called = true
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"id": "A-1", "quantity": 3}`)).WithContext(ctx)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, req)
+
+	// Assert
+	assert.False(t, called)
+	assert.Empty(t, rec.Body.String())
+}
+
+func TestHandler_WhenRequestDeadlineIsExceeded_ShouldRespondWithServiceUnavailable(t *testing.T) {
+	// Arrange
+	called := false
+	v := validator.New[order]()
+	v.AddStepContext(func(ctx context.Context, o order) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	handler := httpvalidator.Handler[order](v, func(http.ResponseWriter, *http.Request, order) {
+		called = true
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"id": "A-1", "quantity": 3}`)).WithContext(ctx)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, req)
+
+	// Assert
+	assert.False(t, called)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	p := problemOf(t, rec)
+	assert.Equal(t, float64(http.StatusServiceUnavailable), p["status"])
+	assert.Equal(t, "validation was interrupted: context deadline exceeded", p["detail"])
+}
+
+func TestFromContext_WhenNoValueWasDecoded_ShouldReturnFalse(t *testing.T) {
+	// Act
+	_, ok := httpvalidator.FromContext[order](context.Background())
+
+	// Assert
+	assert.False(t, ok)
+}
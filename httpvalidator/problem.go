@@ -0,0 +1,51 @@
+package httpvalidator
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cgxarrie-go/validator"
+)
+
+// ContentType is the media type of the problem responses
+const ContentType = "application/problem+json"
+
+// Problem types of the requests whose body cannot be decoded, joined to the
+// base set with WithProblemTypeBase or validator.SetProblemTypeBase
+const (
+	// ProblemTypeInvalidBody is the type of the problems of bodies that are
+	// empty or not valid JSON for the value
+	ProblemTypeInvalidBody = "invalid-body"
+	// ProblemTypeBodyTooLarge is the type of the problems of bodies larger
+	// than the max body size
+	ProblemTypeBodyTooLarge = "body-too-large"
+	// ProblemTypeUnsupportedMediaType is the type of the problems of bodies
+	// whose content type is not JSON
+	ProblemTypeUnsupportedMediaType = "unsupported-media-type"
+)
+
+// newProblem returns the problem of a request whose body cannot be decoded,
+// whose type is joined to base
+func newProblem(base string, status int, detail string) validator.Problem {
+	name := ProblemTypeInvalidBody
+	switch status {
+	case http.StatusRequestEntityTooLarge:
+		name = ProblemTypeBodyTooLarge
+	case http.StatusUnsupportedMediaType:
+		name = ProblemTypeUnsupportedMediaType
+	}
+
+	return validator.Problem{
+		Type:   validator.ProblemType(base, name),
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+func writeProblem(w http.ResponseWriter, p validator.Problem) {
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
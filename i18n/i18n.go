@@ -0,0 +1,234 @@
+// Package i18n translates the failure messages of validation results from
+// message catalogs.
+//
+// A Catalog maps the message keys of the failures, their codes unless a
+// failure sets validator.Failure.Key, to message templates whose
+// placeholders are the parameters of the failure, e.g.
+//
+//	"min_length": "la longitud debe ser de al menos {min}"
+//
+// A Translator holds the catalogs of several locales, loaded from Go maps,
+// JSON files or an fs.FS such as an embed.FS, and is used with
+// validator.Result.Translate:
+//
+//	translator := i18n.New()
+//	result = result.Translate(translator, "es-MX")
+//
+// Messages missing in a locale are looked up in its parent locales and then
+// in the fallback locale, e.g. "es-MX", "es" and "en". The translators
+// returned by New include the built-in catalogs of every bundled rule, in
+// English (en), Spanish (es), French (fr), German (de) and Portuguese (pt).
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/cgxarrie-go/validator"
+)
+
+// DefaultFallback is the locale whose messages are used when the requested
+// locale has none, unless changed with WithFallback
+const DefaultFallback = "en"
+
+//go:embed catalogs/*.json
+var defaults embed.FS
+
+// Catalog maps message keys to message templates. The placeholders of a
+// template, e.g. {max}, are replaced by the parameters of the failure
+type Catalog map[string]string
+
+type config struct {
+	fallback string
+	defaults bool
+}
+
+// Option configures a Translator
+type Option func(*config)
+
+// WithFallback sets the locale whose messages are used when the requested
+// locale and its parents have none
+func WithFallback(locale string) Option {
+	return func(c *config) {
+		c.fallback = locale
+	}
+}
+
+// WithoutDefaults returns a translator without the built-in catalogs
+func WithoutDefaults() Option {
+	return func(c *config) {
+		c.defaults = false
+	}
+}
+
+// Translator renders failure messages from the catalogs of several locales.
+// It implements validator.Translator, and is safe for concurrent use
+type Translator struct {
+	mu       sync.RWMutex
+	fallback string
+	catalogs map[string]Catalog
+}
+
+// New returns a translator with the built-in catalogs, falling back to
+// English
+func New(opts ...Option) *Translator {
+	cfg := config{fallback: DefaultFallback, defaults: true}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	t := &Translator{
+		fallback: normalize(cfg.fallback),
+		catalogs: make(map[string]Catalog),
+	}
+	if cfg.defaults {
+		if err := t.AddFS(defaults, "catalogs"); err != nil {
+			panic(fmt.Sprintf("i18n: built-in catalogs: %v", err))
+		}
+	}
+	return t
+}
+
+// Add adds the messages of catalog to the locale, replacing the messages
+// with the same keys
+func (t *Translator) Add(locale string, catalog Catalog) *Translator {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	locale = normalize(locale)
+	if t.catalogs[locale] == nil {
+		t.catalogs[locale] = make(Catalog, len(catalog))
+	}
+	for key, msg := range catalog {
+		t.catalogs[locale][key] = msg
+	}
+	return t
+}
+
+// AddJSON adds the messages of a JSON object mapping keys to templates to
+// the locale
+func (t *Translator) AddJSON(locale string, data []byte) error {
+	catalog := Catalog{}
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return fmt.Errorf("i18n: catalog %q: %w", locale, err)
+	}
+
+	t.Add(locale, catalog)
+	return nil
+}
+
+// AddFile adds the messages of a JSON file named after its locale, e.g.
+// "messages/es-MX.json"
+func (t *Translator) AddFile(file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("i18n: %w", err)
+	}
+
+	return t.AddJSON(strings.TrimSuffix(filepath.Base(file), ".json"), data)
+}
+
+// AddFS adds the messages of every JSON file in the directory dir of fsys,
+// each named after its locale, e.g. "es-MX.json"
+func (t *Translator) AddFS(fsys fs.FS, dir string) error {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("i18n: %w", err)
+	}
+
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return fmt.Errorf("i18n: %w", err)
+		}
+		if err := t.AddJSON(strings.TrimSuffix(path.Base(file), ".json"), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Translate returns the message of f in the locale, rendered with the
+// params of f, and false when neither the locale, its parents nor the
+// fallback locale have a message for the key of f
+func (t *Translator) Translate(locale string, f validator.Failure) (string, bool) {
+	key := f.MessageKey()
+	if key == "" {
+		return "", false
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, l := range t.chain(locale) {
+		if msg, ok := t.catalogs[l][key]; ok {
+			return render(msg, f.Params), true
+		}
+	}
+	return "", false
+}
+
+// chain returns the locales looked up for locale, e.g. "es-mx", "es" and
+// the fallback locale with its parents
+func (t *Translator) chain(locale string) []string {
+	locales := make([]string, 0, 4)
+	seen := make(map[string]bool)
+
+	for _, l := range []string{normalize(locale), t.fallback} {
+		for l != "" {
+			if !seen[l] {
+				seen[l] = true
+				locales = append(locales, l)
+			}
+			i := strings.LastIndex(l, "-")
+			if i < 0 {
+				break
+			}
+			l = l[:i]
+		}
+	}
+	return locales
+}
+
+// normalize returns the locale in lower case with "-" separators, so
+// "es_MX" and "es-mx" are the same locale
+func normalize(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+var placeholder = regexp.MustCompile(`\{(\w+)\}`)
+
+// render replaces the placeholders of msg by the params. Placeholders with
+// no param are kept
+func render(msg string, params map[string]any) string {
+	return placeholder.ReplaceAllStringFunc(msg, func(p string) string {
+		value, ok := params[p[1:len(p)-1]]
+		if !ok {
+			return p
+		}
+		return format(value)
+	})
+}
+
+// format formats a param, joining the elements of lists with ", "
+func format(value any) string {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Sprint(value)
+	}
+
+	items := make([]string, rv.Len())
+	for i := range items {
+		items[i] = format(rv.Index(i).Interface())
+	}
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,187 @@
+package i18n_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/cgxarrie-go/validator/i18n"
+	"github.com/cgxarrie-go/validator/rules"
+	"github.com/cgxarrie-go/validator/ruleset"
+	"github.com/cgxarrie-go/validator/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type customer struct {
+	Name    string
+	Age     int
+	Website string
+}
+
+func newCustomerValidator() validator.Validator[customer] {
+	v := validator.New[customer]()
+	validator.RuleFor(v, "name", func(c customer) string { return c.Name }).
+		NotEmpty().
+		Check(rules.OneOf("ann", "bob"))
+	validator.RuleFor(v, "age", func(c customer) int { return c.Age }).
+		Check(rules.Between(18, 65))
+	validator.RuleFor(v, "website", func(c customer) string { return c.Website }).
+		Check(rules.URL("https"))
+	return v
+}
+
+func TestTranslate_WhenLocaleHasCatalog_ShouldRenderTheMessagesWithTheParams(t *testing.T) {
+	// Arrange
+	result := newCustomerValidator().Validate(customer{Age: 7, Website: "ftp://example.com"})
+
+	// Act
+	translated := result.Translate(i18n.New(), "es")
+
+	// Assert
+	assert.Equal(t, []string{
+		"name: no debe estar vacío",
+		"name: debe ser uno de [ann, bob]",
+		"age: debe estar entre 18 y 65",
+		"website: debe ser una URL válida con esquema [https]",
+	}, translated.GetFailureMessages())
+	assert.Equal(t, result.Codes(), translated.Codes())
+}
+
+func TestTranslate_WhenLocaleIsEnglish_ShouldRenderTheBundledMessages(t *testing.T) {
+	// Arrange
+	result := newCustomerValidator().Validate(customer{Age: 7, Website: "ftp://example.com"})
+
+	// Act
+	translated := result.Translate(i18n.New(), "en-GB")
+
+	// Assert
+	assert.Equal(t, result.GetFailureMessages(), translated.GetFailureMessages())
+}
+
+func TestTranslate_WhenMessageIsMissing_ShouldFallBackToParentAndFallbackLocales(t *testing.T) {
+	// Arrange
+	translator := i18n.New(i18n.WithoutDefaults()).
+		Add("en", i18n.Catalog{"not_empty": "must not be empty", "min": "must be at least {min}"}).
+		Add("es", i18n.Catalog{"not_empty": "no debe estar vacío"}).
+		Add("es-MX", i18n.Catalog{"not_empty": "no debe quedar vacío"})
+
+	notEmpty := validator.Failure{Code: validator.CodeNotEmpty}
+	minimum := validator.Failure{Code: rules.CodeMin, Params: map[string]any{"min": 3}}
+
+	tests := []struct {
+		name    string
+		locale  string
+		failure validator.Failure
+		want    string
+	}{
+		{name: "locale", locale: "es-MX", failure: notEmpty, want: "no debe quedar vacío"},
+		{name: "underscore locale", locale: "es_mx", failure: notEmpty, want: "no debe quedar vacío"},
+		{name: "parent locale", locale: "es-AR", failure: notEmpty, want: "no debe estar vacío"},
+		{name: "fallback locale", locale: "es-MX", failure: minimum, want: "must be at least 3"},
+		{name: "unknown locale", locale: "ja", failure: notEmpty, want: "must not be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			msg, ok := translator.Translate(tt.locale, tt.failure)
+
+			// Assert
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, msg)
+		})
+	}
+}
+
+func TestTranslate_WhenNoCatalogHasTheKey_ShouldKeepTheMessage(t *testing.T) {
+	// Arrange
+	result := validator.Result{}
+	result.AddFailureMessage("order is closed")
+	result.AddFailure(validator.Failure{Code: "closed", Message: "order is closed"})
+
+	// Act
+	translated := result.Translate(i18n.New(), "fr")
+
+	// Assert
+	assert.Equal(t, result.GetFailureMessages(), translated.GetFailureMessages())
+}
+
+func TestTranslate_WhenFailureHasKey_ShouldUseTheKeyInsteadOfTheCode(t *testing.T) {
+	// Arrange
+	translator := i18n.New(i18n.WithFallback("es")).
+		Add("es", i18n.Catalog{"order.too_late": "los pedidos se cierran a las {hour}"})
+	f := validator.Failure{Code: validator.CodePredicate, Key: "order.too_late", Params: map[string]any{"hour": "18:00"}}
+
+	// Act
+	msg, ok := translator.Translate("de", f)
+
+	// Assert
+	assert.True(t, ok)
+	assert.Equal(t, "los pedidos se cierran a las 18:00", msg)
+}
+
+func TestAddFS_WhenDirHasCatalogs_ShouldAddThemByFileName(t *testing.T) {
+	// Arrange
+	fsys := fstest.MapFS{
+		"messages/es-MX.json": {Data: []byte(`{"not_empty": "no debe quedar vacío"}`)},
+		"messages/notes.txt":  {Data: []byte(`not a catalog`)},
+	}
+	translator := i18n.New()
+
+	// Act
+	err := translator.AddFS(fsys, "messages")
+
+	// Assert
+	assert.NoError(t, err)
+	msg, _ := translator.Translate("es-MX", validator.Failure{Code: validator.CodeNotEmpty})
+	assert.Equal(t, "no debe quedar vacío", msg)
+	msg, _ = translator.Translate("es-MX", validator.Failure{Code: rules.CodeEmail})
+	assert.Equal(t, "debe ser una dirección de correo electrónico válida", msg)
+}
+
+func TestAddFile_WhenFileIsNotJSON_ShouldReturnError(t *testing.T) {
+	// Arrange
+	file := filepath.Join(t.TempDir(), "it.json")
+	assert.NoError(t, os.WriteFile(file, []byte(`{"not_empty": `), 0o600))
+
+	// Act
+	err := i18n.New().AddFile(file)
+
+	// Assert
+	assert.ErrorContains(t, err, `catalog "it"`)
+}
+
+func TestNew_WhenUsingDefaults_ShouldHaveAMessageForEveryBundledKey(t *testing.T) {
+	// Arrange
+	keys := []string{
+		validator.CodeRequired, validator.CodeNotEmpty, validator.CodeMinLength, validator.CodeMaxLength,
+		validator.CodePattern, validator.CodeMinCount, validator.CodeMaxCount, validator.CodeNoSteps,
+		validator.CodeNoValidator, validator.CodeExactlyOne, validator.CodeUndefinedDependency,
+		validator.CodePredicate, validator.CodeNegated, ruleset.CodeInvalidType,
+		rules.CodeNotBlank, rules.CodeLength, rules.CodePrefix, rules.CodeSuffix, rules.CodeContains,
+		rules.CodeOneOf, rules.CodeAlpha, rules.CodeAlphanumeric, rules.CodeASCII, rules.CodePrintable,
+		rules.CodeLowercase, rules.CodeUppercase, rules.CodeTrimmed, rules.CodeUTF8,
+		rules.CodeEmail, rules.CodeURL, rules.KeyURLSchemes, rules.CodeURLReference, rules.CodeUUID,
+		rules.KeyUUIDVersions, rules.CodeIP, rules.CodeIPv4, rules.CodeIPv6, rules.CodeCIDR,
+		rules.CodeHostname, rules.CodeFQDN, rules.CodeMAC, rules.CodePort,
+		rules.CodeMin, rules.CodeMax, rules.CodeBetween, rules.CodeExclusiveBetween, rules.CodeGreaterThan,
+		rules.CodeLessThan, rules.CodeEqual, rules.CodeNotEqual, rules.CodePositive, rules.CodeNegative,
+		rules.CodeNonZero, rules.CodeMultipleOf, rules.CodeFinite, rules.CodeNotNaN, rules.CodeDecimalPlaces,
+		rules.CodeUnique, rules.CodeNotNil, rules.CodeSorted,
+		schema.CodeType, schema.CodeAdditionalProperty, schema.CodeAnyOf, schema.CodeExactlyOneOf,
+		schema.CodeNot, schema.CodeNotAllowed, schema.KeyMinProperties, schema.KeyMaxProperties,
+	}
+	translator := i18n.New(i18n.WithFallback("none"))
+
+	for _, locale := range []string{"en", "es", "fr", "de", "pt"} {
+		for _, key := range keys {
+			// Act
+			_, ok := translator.Translate(locale, validator.Failure{Key: key})
+
+			// Assert
+			assert.True(t, ok, "%s: %s", locale, key)
+		}
+	}
+}
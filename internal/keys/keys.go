@@ -0,0 +1,96 @@
+// Package keys sorts map keys in their natural order, so the failures of the
+// values of a map are reported in the same order on every validation, and
+// tells the values that cannot be used as map keys
+package keys
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Sort sorts the keys of a map, see Less
+func Sort(keys []reflect.Value) {
+	sort.Slice(keys, func(i, j int) bool {
+		return Less(keys[i], keys[j])
+	})
+}
+
+// Less reports whether the key a sorts before b. Integers, floats and
+// strings are sorted by their value, e.g. 2 before 10, and other keys by
+// their text. Keys of different kinds, found in maps of interfaces, are
+// sorted by kind first: integers, floats, strings and then the others
+func Less(a, b reflect.Value) bool {
+	for a.Kind() == reflect.Interface && !a.IsNil() {
+		a = a.Elem()
+	}
+	for b.Kind() == reflect.Interface && !b.IsNil() {
+		b = b.Elem()
+	}
+
+	ca, cb := classOf(a), classOf(b)
+	if ca != cb {
+		return ca < cb
+	}
+
+	switch ca {
+	case classInt:
+		return a.Int() < b.Int()
+	case classUint:
+		return a.Uint() < b.Uint()
+	case classFloat:
+		return a.Float() < b.Float()
+	case classString:
+		return a.String() < b.String()
+	}
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
+
+// Hashable reports whether v can be used as a map key, which is not the case
+// of the interfaces holding maps, slices or funcs, or values containing them
+func Hashable(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Interface:
+		return v.IsNil() || Hashable(v.Elem())
+	case reflect.Map, reflect.Slice, reflect.Func:
+		return false
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !Hashable(v.Index(i)) {
+				return false
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !Hashable(v.Field(i)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// class groups the kinds of keys sorted the same way
+type class int
+
+const (
+	classInt class = iota
+	classUint
+	classFloat
+	classString
+	classOther
+)
+
+func classOf(v reflect.Value) class {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return classInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return classUint
+	case reflect.Float32, reflect.Float64:
+		return classFloat
+	case reflect.String:
+		return classString
+	}
+	return classOther
+}
@@ -0,0 +1,41 @@
+package keys_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgxarrie-go/validator/internal/keys"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSort_WhenKeysAreOrdered_ShouldSortThemByValue(t *testing.T) {
+	tests := []struct {
+		name string
+		m    any
+		want []any
+	}{
+		{name: "ints", m: map[int]bool{10: true, 2: true, -1: true}, want: []any{-1, 2, 10}},
+		{name: "uints", m: map[uint8]bool{10: true, 2: true}, want: []any{uint8(2), uint8(10)}},
+		{name: "floats", m: map[float64]bool{10.5: true, 2.25: true}, want: []any{2.25, 10.5}},
+		{name: "strings", m: map[string]bool{"b": true, "a": true}, want: []any{"a", "b"}},
+		{name: "any", m: map[any]bool{10: true, 2: true, "a": true}, want: []any{2, 10, "a"}},
+		{name: "bools", m: map[bool]bool{true: true, false: true}, want: []any{false, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mapKeys := reflect.ValueOf(tt.m).MapKeys()
+
+			// Act
+			keys.Sort(mapKeys)
+
+			// Assert
+			got := make([]any, len(mapKeys))
+			for i, k := range mapKeys {
+				got[i] = k.Interface()
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package validator
+
+import "context"
+
+// CodeRequired is used when a required nested value is nil
+const CodeRequired = "required"
+
+type nestedChain[T any, N any] struct {
+	owner     *validator[T]
+	step      *validationStep[T]
+	name      string
+	get       func(T) *N
+	validator Validator[N]
+	required  bool
+}
+
+// SetValidator adds a validation step to v that runs child on the nested
+// value returned by get. Every failure of child is prefixed with name, e.g.
+// "address.city".
+//
+// When get returns nil the step is skipped, unless the nested value is set
+// as required. For nested values that are not pointers, get can return the
+// address of the field
+func SetValidator[T any, N any, V Validator[N]](v *validator[T], name string, get func(T) *N, child V) *nestedChain[T, N] {
+	chain := &nestedChain[T, N]{
+		owner:     v,
+		name:      name,
+		get:       get,
+		validator: child,
+	}
+	chain.step = v.AddStepContext(chain.validate)
+	chain.step.describe = func() Description {
+		return Describe(chain.validator).field(name, chain.required)
+	}
+
+	return chain
+}
+
+// Required makes the step fail when the nested value is nil
+func (n *nestedChain[T, N]) Required() *nestedChain[T, N] {
+	n.required = true
+	return n
+}
+
+// BreakOnFailure forces the validator to stop processing steps if the nested
+// validation fails
+func (n *nestedChain[T, N]) BreakOnFailure() *nestedChain[T, N] {
+	n.step.BreakOnFailure()
+	return n
+}
+
+// When makes the step of the chain run only when predicate returns true for
+// the validated value
+func (n *nestedChain[T, N]) When(predicate func(T) bool) *nestedChain[T, N] {
+	n.step.When(predicate)
+	return n
+}
+
+// Unless makes the step of the chain run only when predicate returns false
+// for the validated value
+func (n *nestedChain[T, N]) Unless(predicate func(T) bool) *nestedChain[T, N] {
+	n.step.Unless(predicate)
+	return n
+}
+
+// Named names the step of the chain, so other steps can depend on it
+func (n *nestedChain[T, N]) Named(name string) *nestedChain[T, N] {
+	n.step.Named(name)
+	return n
+}
+
+// DependsOn makes the step of the chain run only when the named steps passed
+func (n *nestedChain[T, N]) DependsOn(names ...string) *nestedChain[T, N] {
+	n.step.DependsOn(names...)
+	return n
+}
+
+// WithSeverity reports the errors of the nested validation with the given
+// severity, e.g. as warnings that do not make the validation fail
+func (n *nestedChain[T, N]) WithSeverity(severity Severity) *nestedChain[T, N] {
+	n.step.WithSeverity(severity)
+	return n
+}
+
+func (n *nestedChain[T, N]) validate(ctx context.Context, src T) error {
+	value := n.get(src)
+	if value == nil {
+		if !n.required {
+			return nil
+		}
+
+		return Failure{
+			Path:    n.name,
+			Code:    CodeRequired,
+			Message: "is required",
+		}
+	}
+
+	res := validateContext(ctx, n.validator, *value)
+	if res.isEmpty() {
+		return nil
+	}
+
+	result := Result{}
+	result.addAtPath(n.name, nil, res)
+	return result
+}
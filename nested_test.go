@@ -0,0 +1,124 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	City    string
+	Country string
+}
+
+type person struct {
+	Name     string
+	Home     address
+	Billing  *address
+	Shipping *address
+}
+
+func newAddressValidator() validator.Validator[address] {
+	v := validator.New[address]()
+	validator.RuleFor(v, "city", func(a address) string { return a.City }).
+		NotEmpty()
+	validator.RuleFor(v, "country", func(a address) string { return a.Country }).
+		NotEmpty().
+		MaxLen(2)
+	return v
+}
+
+func Test_SetValidator_WhenNestedValueFails_ShouldPrefixFailuresWithFieldName(t *testing.T) {
+	// Arrange
+	req := person{
+		Home:    address{City: "", Country: "ES"},
+		Billing: &address{City: "Barcelona", Country: "Spain"},
+	}
+
+	v := validator.New[person]()
+	validator.SetValidator(v, "home", func(p person) *address { return &p.Home }, newAddressValidator())
+	validator.SetValidator(v, "billing", func(p person) *address { return p.Billing }, newAddressValidator())
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	failures := result.Failures()
+	assert.Len(t, failures, 2)
+	assert.Equal(t, "home.city", failures[0].Path)
+	assert.Equal(t, "billing.country", failures[1].Path)
+	assert.Equal(t, validator.CodeMaxLength, failures[1].Code)
+}
+
+func Test_SetValidator_WhenNestedPointerIsNil_ShouldSkip(t *testing.T) {
+	// Arrange
+	req := person{Home: address{City: "Barcelona", Country: "ES"}}
+
+	v := validator.New[person]()
+	validator.SetValidator(v, "shipping", func(p person) *address { return p.Shipping }, newAddressValidator())
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	assert.True(t, result.IsSuccess())
+}
+
+func Test_SetValidator_WhenRequiredNestedPointerIsNil_ShouldFail(t *testing.T) {
+	// Arrange
+	req := person{}
+
+	v := validator.New[person]()
+	validator.SetValidator(v, "shipping", func(p person) *address { return p.Shipping }, newAddressValidator()).
+		Required()
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	failures := result.Failures()
+	assert.Len(t, failures, 1)
+	assert.Equal(t, "shipping", failures[0].Path)
+	assert.Equal(t, validator.CodeRequired, failures[0].Code)
+}
+
+func Test_SetValidator_WhenBreakOnFailureIsSet_ShouldStopProcessingSteps(t *testing.T) {
+	// Arrange
+	req := person{Billing: &address{}}
+
+	v := validator.New[person]()
+	validator.SetValidator(v, "billing", func(p person) *address { return p.Billing }, newAddressValidator()).
+		BreakOnFailure()
+	validator.RuleFor(v, "name", func(p person) string { return p.Name }).
+		NotEmpty()
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	assert.Len(t, result.FailuresFor("billing.city"), 1)
+	assert.Len(t, result.FailuresFor("name"), 0)
+}
+
+func Test_SetValidator_WhenNestingIsDeep_ShouldJoinAllPaths(t *testing.T) {
+	// Arrange
+	type company struct {
+		Owner person
+	}
+	req := company{Owner: person{Billing: &address{Country: "ES"}}}
+
+	personValidator := validator.New[person]()
+	validator.SetValidator(personValidator, "billing", func(p person) *address { return p.Billing }, newAddressValidator())
+
+	v := validator.New[company]()
+	validator.SetValidator(v, "owner", func(c company) *person { return &c.Owner }, personValidator)
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	failures := result.Failures()
+	assert.Len(t, failures, 1)
+	assert.Equal(t, "owner.billing.city", failures[0].Path)
+}
@@ -0,0 +1,119 @@
+package validator
+
+import (
+	"context"
+	"sync"
+)
+
+// Parallel makes the validator run its steps concurrently, using at most
+// workers goroutines. Failures are reported in step order, as if the steps
+// were run sequentially.
+//
+// Steps set to break on failure act as barriers: the steps added after them
+// are not started until they have completed, and are not run at all if they
+// fail. When the validator itself is set to break on failure, every step is
+// a barrier, so the steps are run one at a time and none is run after the
+// first failing step. Steps depending on other
+// steps, see DependsOn, are not started until their dependencies completed.
+//
+// A value of workers lower than 2 runs the steps sequentially
+func (v *validator[T]) Parallel(workers int) *validator[T] {
+	v.workers = workers
+	return v
+}
+
+func (v validator[T]) validateParallel(ctx context.Context, src T) Result {
+	result := Result{}
+	steps := v.order()
+	failed := make(map[*validationStep[T]]bool)
+
+	for start := 0; start < len(steps); {
+		end := v.segmentEnd(steps, start)
+		segment := steps[start : end+1]
+
+		run := make([]*validationStep[T], 0, len(segment))
+		blocked := make(map[*validationStep[T]]Result)
+		for _, step := range segment {
+			if b, ok := v.blocked(step, failed); ok {
+				blocked[step] = b
+				continue
+			}
+			run = append(run, step)
+		}
+
+		errs := v.runConcurrently(ctx, run, src)
+
+		i := 0
+		for _, step := range segment {
+			if b, ok := blocked[step]; ok {
+				result.Merge(b)
+				failed[step] = true
+				if b.IsFailure() && (step.breakOnFailure || v.breakOnFailure) {
+					return result
+				}
+				continue
+			}
+
+			before := len(result.failures)
+			stop := v.addStepError(ctx, &result, step, errs[i])
+			failed[step] = len(result.failures) > before
+			if stop {
+				return result
+			}
+			i++
+		}
+
+		start = end + 1
+	}
+
+	return result
+}
+
+// segmentEnd returns the index of the last step of the segment of steps run
+// concurrently starting at start. A segment ends at a step set to break on
+// failure, at every step when the validator is, and before a step depending
+// on a step of the segment
+func (v validator[T]) segmentEnd(steps []*validationStep[T], start int) int {
+	segment := map[*validationStep[T]]bool{steps[start]: true}
+	end := start
+	for end < len(steps)-1 && !v.breakOnFailure && !steps[end].breakOnFailure {
+		next := steps[end+1]
+		for _, name := range next.dependsOn {
+			if dependency, ok := v.steps[name]; ok && segment[dependency] {
+				return end
+			}
+		}
+		segment[next] = true
+		end++
+	}
+	return end
+}
+
+// runConcurrently runs the steps in a pool of v.workers goroutines and
+// returns their errors in step order
+func (v validator[T]) runConcurrently(ctx context.Context, steps []*validationStep[T], src T) []error {
+	errs := make([]error, len(steps))
+	sem := make(chan struct{}, v.workers)
+	wg := sync.WaitGroup{}
+
+	for i, step := range steps {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, step *validationStep[T]) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				errs[i] = ctxErr
+				return
+			}
+			errs[i] = step.run(ctx, src)
+		}(i, step)
+	}
+
+	wg.Wait()
+	return errs
+}
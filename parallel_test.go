@@ -0,0 +1,152 @@
+package validator_test
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+func slowStep(delay time.Duration, err error) func(dummyType) error {
+	return func(dummyType) error {
+		time.Sleep(delay)
+		return err
+	}
+}
+
+func Test_Parallel_ShouldReportFailuresInStepOrder(t *testing.T) {
+	// Arrange
+	req := dummyType{}
+
+	v := validator.New[dummyType]().Parallel(4)
+	v.AddStep(slowStep(30*time.Millisecond, errors.New("Error-1")))
+	v.AddStep(slowStep(20*time.Millisecond, errors.New("Error-2")))
+	v.AddStep(slowStep(10*time.Millisecond, nil))
+	v.AddStep(slowStep(0, errors.New("Error-4")))
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	assert.Equal(t, []string{"Error-1", "Error-2", "Error-4"}, result.GetFailureMessages())
+}
+
+func Test_Parallel_ShouldNotExceedWorkers(t *testing.T) {
+	// Arrange
+	req := dummyType{}
+	var running, maxRunning int32
+
+	step := func(dummyType) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	}
+
+	v := validator.New[dummyType]().Parallel(3)
+	for i := 0; i < 10; i++ {
+		v.AddStep(step)
+	}
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	assert.True(t, result.IsSuccess())
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(3))
+	assert.Greater(t, atomic.LoadInt32(&maxRunning), int32(1))
+}
+
+func Test_Parallel_WhenStepBreakOnFailureIsSet_ShouldActAsBarrier(t *testing.T) {
+	// Arrange
+	req := dummyType{}
+	var ranAfterBarrier int32
+
+	v := validator.New[dummyType]().Parallel(4)
+	v.AddStep(slowStep(20*time.Millisecond, errors.New("Error-1")))
+	v.AddStep(slowStep(0, errors.New("Error-2"))).BreakOnFailure()
+	v.AddStep(func(dummyType) error {
+		atomic.AddInt32(&ranAfterBarrier, 1)
+		return errors.New("Error-3")
+	})
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	assert.Equal(t, []string{"Error-1", "Error-2"}, result.GetFailureMessages())
+	assert.Equal(t, int32(0), atomic.LoadInt32(&ranAfterBarrier))
+}
+
+func Test_Parallel_WhenBarrierPasses_ShouldRunNextSteps(t *testing.T) {
+	// Arrange
+	req := dummyType{}
+
+	v := validator.New[dummyType]().Parallel(4)
+	v.AddStep(slowStep(10*time.Millisecond, errors.New("Error-1")))
+	v.AddStep(slowStep(0, nil)).BreakOnFailure()
+	v.AddStep(slowStep(0, errors.New("Error-3")))
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	assert.Equal(t, []string{"Error-1", "Error-3"}, result.GetFailureMessages())
+}
+
+func Test_Parallel_WhenValidatorBreakOnFailureIsSet_ShouldNotRunStepsAfterFirstFailure(t *testing.T) {
+	// Arrange
+	req := dummyType{}
+	var ranAfterFailure int32
+
+	v := validator.New[dummyType]().BreakOnFailure().Parallel(4)
+	v.AddStep(slowStep(0, nil))
+	v.AddStep(slowStep(20*time.Millisecond, errors.New("Error-2")))
+	v.AddStep(func(dummyType) error {
+		atomic.AddInt32(&ranAfterFailure, 1)
+		return errors.New("Error-3")
+	})
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	assert.Equal(t, []string{"Error-2"}, result.GetFailureMessages())
+	assert.Equal(t, int32(0), atomic.LoadInt32(&ranAfterFailure))
+}
+
+func Test_Parallel_ShouldMatchSequentialResult(t *testing.T) {
+	// Arrange
+	req := customer{Name: "", Tags: []string{"a", "b", "c"}}
+
+	build := func(workers int) validator.Validator[customer] {
+		v := validator.New[customer]().Parallel(workers)
+		validator.RuleFor(v, "name", func(c customer) string { return c.Name }).
+			NotEmpty()
+		validator.RuleFor(v, "tags", func(c customer) []string { return c.Tags }).
+			MaxLen(2)
+		for i := 0; i < 5; i++ {
+			i := i
+			v.AddStep(func(customer) error { return fmt.Errorf("Error-%d", i) })
+		}
+		return v
+	}
+
+	// Act
+	sequential := build(1).Validate(req)
+	parallel := build(4).Validate(req)
+
+	// Assert
+	assert.Equal(t, sequential.GetFailureMessages(), parallel.GetFailureMessages())
+	assert.Len(t, parallel.Failures(), 7)
+}
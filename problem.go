@@ -0,0 +1,125 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// Problem types of the problem details of a result, joined to the base set
+// with SetProblemTypeBase
+const (
+	// ProblemTypeValidation is the type of the problems of failed results
+	ProblemTypeValidation = "validation-error"
+	// ProblemTypeInterrupted is the type of the problems of results whose
+	// validation was interrupted by its context
+	ProblemTypeInterrupted = "validation-interrupted"
+)
+
+// Problem is an RFC 9457 (formerly RFC 7807) problem details document,
+// describing a failed validation to API clients. The problem of a successful
+// result has no type and no status
+type Problem struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title"`
+	Status   int    `json:"status,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Errors lists the failures of the result, as the "errors" extension
+	// member of the problem
+	Errors []ProblemError `json:"errors,omitempty"`
+}
+
+// ProblemError is a failure listed in the "errors" member of a problem
+type ProblemError struct {
+	Path    string `json:"path,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+	Branch  string `json:"branch,omitempty"`
+}
+
+var problemTypeBase atomic.Value
+
+// SetProblemTypeBase sets the default base URI of the problem types, used by
+// Problem and by MarshalJSON, e.g. with "https://errors.example.com/" failed
+// results have the type "https://errors.example.com/validation-error". With
+// an empty base, the default, the type is "about:blank" and the title is the
+// HTTP status text. The base is shared by the whole process, use
+// ProblemWithBase to render a result with a base of its own
+func SetProblemTypeBase(base string) {
+	problemTypeBase.Store(base)
+}
+
+// ProblemTypeBase returns the default base URI of the problem types, set with
+// SetProblemTypeBase
+func ProblemTypeBase() string {
+	base, _ := problemTypeBase.Load().(string)
+	return base
+}
+
+// ProblemType returns the type URI of the problems called name, joined to
+// base, or "about:blank" when base is empty, so other problems of a service
+// have types like the ones of the results
+func ProblemType(base, name string) string {
+	if base == "" {
+		return "about:blank"
+	}
+	return strings.TrimSuffix(base, "/") + "/" + name
+}
+
+// Problem returns the problem details of the result: 400 Bad Request listing
+// its failures, or 503 Service Unavailable when the validation was
+// interrupted by its context. A successful result is not a problem, and is
+// rendered with the title "Validation passed" only, without type nor status.
+// The types are joined to the base set with SetProblemTypeBase
+func (e Result) Problem() Problem {
+	return e.ProblemWithBase(ProblemTypeBase())
+}
+
+// ProblemWithBase returns the problem details of the result like Problem,
+// with types joined to base instead of the base set with SetProblemTypeBase
+func (e Result) ProblemWithBase(base string) Problem {
+	if e.IsSuccess() {
+		return Problem{Title: "Validation passed"}
+	}
+
+	p := Problem{
+		Type:   ProblemType(base, ProblemTypeValidation),
+		Title:  "Validation failed",
+		Status: 400,
+		Detail: fmt.Sprintf("%d validation failures", len(e.failures)),
+	}
+	if len(e.failures) == 1 {
+		p.Detail = "1 validation failure"
+	}
+	if base == "" {
+		p.Title = "Bad Request"
+	}
+
+	if e.contextErr != nil {
+		p.Type = ProblemType(base, ProblemTypeInterrupted)
+		p.Title = "Validation interrupted"
+		p.Status = 503
+		p.Detail = "validation was interrupted: " + e.contextErr.Error()
+		if base == "" {
+			p.Title = "Service Unavailable"
+		}
+	}
+
+	for _, f := range e.failures {
+		p.Errors = append(p.Errors, ProblemError{
+			Path:    f.Path,
+			Code:    f.Code,
+			Message: f.Message,
+			Branch:  f.Branch,
+		})
+	}
+	return p
+}
+
+// MarshalJSON implements json.Marshaler, rendering the result as its problem
+// details document
+func (e Result) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.Problem())
+}
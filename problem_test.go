@@ -0,0 +1,144 @@
+package validator_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+func failedResult() validator.Result {
+	result := validator.Result{}
+	result.AddFailure(validator.Failure{Path: "name", Code: validator.CodeNotEmpty, Message: "must not be empty"})
+	result.AddFailure(validator.Failure{Path: "lines[0].quantity", Code: "min", Message: "must be greater than or equal to 1"})
+	return result
+}
+
+func Test_Problem_WhenResultFails_ShouldListFailures(t *testing.T) {
+	// Arrange
+	result := failedResult()
+
+	// Act
+	p := result.Problem()
+
+	// Assert
+	assert.Equal(t, "about:blank", p.Type)
+	assert.Equal(t, "Bad Request", p.Title)
+	assert.Equal(t, 400, p.Status)
+	assert.Equal(t, "2 validation failures", p.Detail)
+	assert.Equal(t, []validator.ProblemError{
+		{Path: "name", Code: validator.CodeNotEmpty, Message: "must not be empty"},
+		{Path: "lines[0].quantity", Code: "min", Message: "must be greater than or equal to 1"},
+	}, p.Errors)
+}
+
+func Test_Problem_WhenTypeBaseIsSet_ShouldUseItInTheType(t *testing.T) {
+	// Arrange
+	validator.SetProblemTypeBase("https://errors.example.com/")
+	defer validator.SetProblemTypeBase("")
+
+	result := validator.Result{}
+	result.AddFailureMessage("is not valid")
+
+	// Act
+	p := result.Problem()
+
+	// Assert
+	assert.Equal(t, "https://errors.example.com/"+validator.ProblemTypeValidation, p.Type)
+	assert.Equal(t, "Validation failed", p.Title)
+	assert.Equal(t, "1 validation failure", p.Detail)
+}
+
+func Test_Problem_WhenValidationIsInterrupted_ShouldReportServiceUnavailable(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	v := validator.New[string]()
+	v.AddStep(func(string) error { return nil })
+
+	// Act
+	p := v.ValidateContext(ctx, "").Problem()
+
+	// Assert
+	assert.Equal(t, 503, p.Status)
+	assert.Equal(t, "Service Unavailable", p.Title)
+	assert.Equal(t, "validation was interrupted: context canceled", p.Detail)
+}
+
+func Test_Problem_WhenResultSucceeds_ShouldHaveNoTypeNorStatus(t *testing.T) {
+	// Arrange
+	result := validator.Result{}
+
+	// Act
+	p := result.Problem()
+	data, err := json.Marshal(result)
+
+	// Assert
+	assert.Equal(t, validator.Problem{Title: "Validation passed"}, p)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"title": "Validation passed"}`, string(data))
+}
+
+func Test_ProblemWithBase_WhenBaseIsGiven_ShouldUseItInsteadOfTheDefault(t *testing.T) {
+	// Arrange
+	validator.SetProblemTypeBase("https://errors.example.com/")
+	defer validator.SetProblemTypeBase("")
+
+	result := failedResult()
+
+	// Act
+	own := result.ProblemWithBase("https://orders.example.com")
+	blank := result.ProblemWithBase("")
+
+	// Assert
+	assert.Equal(t, "https://orders.example.com/"+validator.ProblemTypeValidation, own.Type)
+	assert.Equal(t, "Validation failed", own.Title)
+	assert.Equal(t, "about:blank", blank.Type)
+	assert.Equal(t, "Bad Request", blank.Title)
+}
+
+func Test_ProblemType_WhenBaseIsSet_ShouldJoinTheName(t *testing.T) {
+	tests := []struct {
+		name string
+		base string
+		want string
+	}{
+		{name: "base", base: "https://errors.example.com", want: "https://errors.example.com/invalid-body"},
+		{name: "base with slash", base: "https://errors.example.com/", want: "https://errors.example.com/invalid-body"},
+		{name: "no base", base: "", want: "about:blank"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			typ := validator.ProblemType(tt.base, "invalid-body")
+
+			// Assert
+			assert.Equal(t, tt.want, typ)
+		})
+	}
+}
+
+func Test_MarshalJSON_WhenResultFails_ShouldRenderProblemDetails(t *testing.T) {
+	// Arrange
+	result := failedResult()
+
+	// Act
+	data, err := json.Marshal(result)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Bad Request",
+		"status": 400,
+		"detail": "2 validation failures",
+		"errors": [
+			{"path": "name", "code": "not_empty", "message": "must not be empty"},
+			{"path": "lines[0].quantity", "code": "min", "message": "must be greater than or equal to 1"}
+		]
+	}`, string(data))
+}
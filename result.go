@@ -2,21 +2,34 @@ package validator
 
 import (
 	"errors"
+	"reflect"
 	"strings"
 )
 
 // Result represent the result of a validation process
+// Failures are tracked by severity: only errors make the result a failure,
+// while warnings and infos are reported by Warnings and Infos
 type Result struct {
-	failures []error
+	failures   []Failure
+	warnings   []Failure
+	infos      []Failure
+	skipped    []SkippedStep
+	contextErr error
 }
 
 // Error implements the error interface. Return all the error messages in the Result joined by semicolon (;)
+// When the validation was interrupted by its context, the context error message is added at the end
 func (e Result) Error() string {
 	if e.IsSuccess() {
 		return ""
 	}
 
-	return strings.Join(e.GetFailureMessages(), ";")
+	msgs := e.GetFailureMessages()
+	if e.contextErr != nil {
+		msgs = append(msgs, e.contextErr.Error())
+	}
+
+	return strings.Join(msgs, ";")
 }
 
 // AddFailureMessage adds a validation failure message to the Result
@@ -27,23 +40,103 @@ func (e *Result) AddFailureMessage(msg string) {
 		return
 	}
 
-	err := errors.New(msg)
-	e.AddFailure(err)
+	e.failures = append(e.failures, Failure{Message: msg})
 }
 
 // AddFailure adds a validation failure to the Result
-// msg is the message to be added
-// if the message to be added is empty or whitespaces, nothing is added
+// failure is the error to be added
+// if failure is a Failure, it is added as is, as an error, warning or info by its severity
+// if failure is a Result, all its failures are added
+// if failure joins several errors with errors.Join, each of them is added
+// other errors wrapping several errors, e.g. with fmt.Errorf or custom multi-errors, are added as a single Failure
+// any other error is added as a Failure whose message is the error message
+// if failure is nil, nothing is added
 func (e *Result) AddFailure(failure error) {
-	if failure == nil {
+	switch f := failure.(type) {
+	case nil:
 		return
+	case Failure:
+		e.add(f)
+	case *Failure:
+		if f != nil {
+			e.add(*f)
+		}
+	case Result:
+		e.Merge(f)
+	case *Result:
+		if f != nil {
+			e.Merge(*f)
+		}
+	default:
+		if errs, ok := joined(f); ok {
+			for _, err := range errs {
+				e.AddFailure(err)
+			}
+			return
+		}
+		e.failures = append(e.failures, Failure{Message: f.Error(), Err: f})
+	}
+}
+
+// joinType is the type of the errors built with errors.Join
+var joinType = reflect.TypeOf(errors.Join(errors.New("a"), errors.New("b")))
+
+// joined returns the errors joined by err when it was built with errors.Join.
+// Other errors wrapping several errors are not joined
+func joined(err error) ([]error, bool) {
+	if reflect.TypeOf(err) != joinType {
+		return nil, false
+	}
+	return err.(interface{ Unwrap() []error }).Unwrap(), true
+}
+
+// add adds f to the failures of its severity
+func (e *Result) add(f Failure) {
+	switch f.Severity {
+	case SeverityWarning:
+		e.warnings = append(e.warnings, f)
+	case SeverityInfo:
+		e.infos = append(e.infos, f)
+	default:
+		e.failures = append(e.failures, f)
+	}
+}
+
+// Unwrap returns the failures of the result, followed by the context error
+// that interrupted the validation, if any. It lets errors.Is and errors.As
+// find the errors returned by the steps, e.g. errors.Is(result, ErrNotFound)
+func (e Result) Unwrap() []error {
+	errs := make([]error, 0, len(e.failures)+1)
+	for _, f := range e.failures {
+		errs = append(errs, f)
+	}
+	if e.contextErr != nil {
+		errs = append(errs, e.contextErr)
+	}
+	return errs
+}
+
+// Merge adds all the failures, warnings and infos of other to the Result
+func (e *Result) Merge(other Result) {
+	e.failures = append(e.failures, other.failures...)
+	e.warnings = append(e.warnings, other.warnings...)
+	e.infos = append(e.infos, other.infos...)
+	e.skipped = append(e.skipped, other.skipped...)
+	if e.contextErr == nil {
+		e.contextErr = other.contextErr
 	}
-	e.failures = append(e.failures, failure)
 }
 
-// IsSuccess returns true when no error has been added to the result. Otherwise, it return false
+// IsSuccess returns true when no error has been added to the result and the validation was not
+// interrupted by its context. Otherwise, it return false. Warnings and infos are not considered
 func (e Result) IsSuccess() bool {
-	return len(e.failures) == 0
+	return len(e.failures) == 0 && e.contextErr == nil
+}
+
+// ContextErr returns the context error that interrupted the validation, or nil when the validation
+// ran to completion. Steps not run because of the interruption are not reported as failures
+func (e Result) ContextErr() error {
+	return e.contextErr
 }
 
 // IsFailure returns true when any error has been added to the result. Otherwise, it return false
@@ -51,12 +144,170 @@ func (e Result) IsFailure() bool {
 	return !e.IsSuccess()
 }
 
+// AddFailureAt adds a validation failure to the Result, joining path with the path of the failure
+// if failure is a Result, path is joined with the path of each of its failures
+// e.g. adding a failure with path "city" at path "address" results in "address.city"
+func (e *Result) AddFailureAt(path string, failure error) {
+	e.addAtPath(path, nil, failure)
+}
+
+// addAtPath adds err to the Result, joining path with the path of every
+// failure. When value is not nil, it is set as the value of the failures
+// with an unknown value
+func (e *Result) addAtPath(path string, value any, err error) {
+	failures := Result{}
+	failures.AddFailure(err)
+
+	for _, f := range failures.all() {
+		f.Path = joinPath(path, f.Path)
+		if f.Value == nil {
+			f.Value = value
+		}
+		e.add(f)
+	}
+
+	if e.contextErr == nil {
+		e.contextErr = failures.contextErr
+	}
+}
+
+// Warnings returns the failures of the result with warning severity
+func (e Result) Warnings() []Failure {
+	return e.warnings
+}
+
+// HasWarnings returns true when any warning has been added to the result
+func (e Result) HasWarnings() bool {
+	return len(e.warnings) > 0
+}
+
+// Infos returns the failures of the result with info severity
+func (e Result) Infos() []Failure {
+	return e.infos
+}
+
+// Strict returns a copy of the result whose warnings are errors, so a result
+// with warnings is a failure
+func (e Result) Strict() Result {
+	strict := Result{
+		failures:   make([]Failure, 0, len(e.failures)+len(e.warnings)),
+		infos:      e.infos,
+		skipped:    e.skipped,
+		contextErr: e.contextErr,
+	}
+
+	strict.failures = append(strict.failures, e.failures...)
+	for _, f := range e.warnings {
+		f.Severity = SeverityError
+		strict.failures = append(strict.failures, f)
+	}
+	return strict
+}
+
+// withSeverity returns a copy of the result whose errors have the given
+// severity
+func (e Result) withSeverity(severity Severity) Result {
+	if severity == SeverityError {
+		return e
+	}
+
+	result := Result{
+		warnings:   append([]Failure(nil), e.warnings...),
+		infos:      append([]Failure(nil), e.infos...),
+		skipped:    e.skipped,
+		contextErr: e.contextErr,
+	}
+	for _, f := range e.failures {
+		f.Severity = severity
+		result.add(f)
+	}
+	return result
+}
+
+// withBranch returns a copy of the result whose failures are labeled with the
+// branch label, prepended to the label of the inner branches
+func (e Result) withBranch(label string) Result {
+	if label == "" {
+		return e
+	}
+
+	result := Result{skipped: e.skipped, contextErr: e.contextErr}
+	for _, f := range e.all() {
+		if f.Branch == "" {
+			f.Branch = label
+		} else {
+			f.Branch = label + "/" + f.Branch
+		}
+		result.add(f)
+	}
+	return result
+}
+
+// all returns the errors, warnings and infos of the result
+func (e Result) all() []Failure {
+	all := make([]Failure, 0, len(e.failures)+len(e.warnings)+len(e.infos))
+	all = append(all, e.failures...)
+	all = append(all, e.warnings...)
+	return append(all, e.infos...)
+}
+
+// Skipped returns the steps that were not run because a step they depend on
+// failed, see DependsOn
+func (e Result) Skipped() []SkippedStep {
+	return e.skipped
+}
+
+// isEmpty returns true when nothing, not even a warning, an info or a
+// skipped step, has been added to the result
+func (e Result) isEmpty() bool {
+	return e.IsSuccess() && len(e.warnings) == 0 && len(e.infos) == 0 && len(e.skipped) == 0
+}
+
 // GetFailures returns a list of all errors in the result
 // If no errors are found return and empty slice
 func (e Result) GetFailures() []error {
+	s := make([]error, len(e.failures))
+
+	for i, v := range e.failures {
+		s[i] = v
+	}
+	return s
+}
+
+// Failures returns a list of all the structured failures in the result
+func (e Result) Failures() []Failure {
 	return e.failures
 }
 
+// FailuresFor returns the failures whose path is exactly the given path
+// If no failures are found return an empty slice
+func (e Result) FailuresFor(path string) []Failure {
+	s := make([]Failure, 0)
+
+	for _, v := range e.failures {
+		if v.Path == path {
+			s = append(s, v)
+		}
+	}
+	return s
+}
+
+// Codes returns the distinct non-empty failure codes in the result, in the
+// order they were first added
+func (e Result) Codes() []string {
+	s := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, v := range e.failures {
+		if v.Code == "" || seen[v.Code] {
+			continue
+		}
+		seen[v.Code] = true
+		s = append(s, v.Code)
+	}
+	return s
+}
+
 // GetFailureMessages returns a list of all errors in the result
 // If no errors are found return and empty slice
 func (e Result) GetFailureMessages() []string {
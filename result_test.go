@@ -1,8 +1,11 @@
 package validator
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -111,3 +114,197 @@ func TestResult_Errors(t *testing.T) {
 		})
 	}
 }
+
+func TestResult_AddFailure(t *testing.T) {
+	nested := Result{}
+	nested.AddFailure(Failure{Path: "name", Code: "required", Message: "is required"})
+	nested.AddFailureMessage("nested message")
+
+	tests := []struct {
+		name     string
+		failure  error
+		expected []Failure
+	}{
+		{name: "nil", failure: nil, expected: nil},
+		{
+			name:     "plain error",
+			failure:  errors.New("error 1"),
+			expected: []Failure{{Message: "error 1"}},
+		},
+		{
+			name:     "failure",
+			failure:  Failure{Path: "age", Code: "min", Message: "too low", Value: 3, Params: map[string]any{"min": 18}},
+			expected: []Failure{{Path: "age", Code: "min", Message: "too low", Value: 3, Params: map[string]any{"min": 18}}},
+		},
+		{
+			name:     "nested result",
+			failure:  nested,
+			expected: []Failure{{Path: "name", Code: "required", Message: "is required"}, {Message: "nested message"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := Result{}
+			e.AddFailure(tt.failure)
+
+			assert.Len(t, e.Failures(), len(tt.expected))
+			for i, want := range tt.expected {
+				got := e.Failures()[i]
+				assert.Equal(t, want.Path, got.Path)
+				assert.Equal(t, want.Code, got.Code)
+				assert.Equal(t, want.Message, got.Message)
+				assert.Equal(t, want.Value, got.Value)
+				assert.Equal(t, want.Params, got.Params)
+			}
+		})
+	}
+}
+
+func TestResult_AddFailure_KeepsOriginalError(t *testing.T) {
+	original := errors.New("original")
+
+	e := Result{}
+	e.AddFailure(original)
+
+	assert.True(t, errors.Is(e.GetFailures()[0], original))
+}
+
+func TestResult_FailuresFor(t *testing.T) {
+	e := Result{}
+	e.AddFailure(Failure{Path: "name", Code: "required", Message: "is required"})
+	e.AddFailure(Failure{Path: "age", Code: "min", Message: "too low"})
+	e.AddFailure(Failure{Path: "name", Code: "max_length", Message: "too long"})
+
+	failures := e.FailuresFor("name")
+
+	assert.Len(t, failures, 2)
+	assert.Equal(t, "required", failures[0].Code)
+	assert.Equal(t, "max_length", failures[1].Code)
+	assert.Len(t, e.FailuresFor("email"), 0)
+}
+
+func TestResult_Codes(t *testing.T) {
+	e := Result{}
+	e.AddFailure(Failure{Path: "name", Code: "required", Message: "is required"})
+	e.AddFailureMessage("no code")
+	e.AddFailure(Failure{Path: "age", Code: "min", Message: "too low"})
+	e.AddFailure(Failure{Path: "email", Code: "required", Message: "is required"})
+
+	assert.Equal(t, []string{"required", "min"}, e.Codes())
+}
+
+func TestResult_GetFailureMessages_IncludesPath(t *testing.T) {
+	e := Result{}
+	e.AddFailure(Failure{Path: "name", Message: "is required"})
+	e.AddFailureMessage("whole value is invalid")
+
+	assert.Equal(t, []string{"name: is required", "whole value is invalid"}, e.GetFailureMessages())
+	assert.Equal(t, "name: is required;whole value is invalid", e.Error())
+}
+
+func TestResult_AddFailureAt(t *testing.T) {
+	nested := Result{}
+	nested.AddFailure(Failure{Path: "city", Message: "is required"})
+	nested.AddFailure(Failure{Path: "[2]", Message: "is duplicated"})
+	nested.AddFailureMessage("is invalid")
+
+	e := Result{}
+	e.AddFailureAt("address", nested)
+	e.AddFailureAt("", errors.New("plain"))
+
+	assert.Equal(t, []string{"address.city: is required", "address[2]: is duplicated", "address: is invalid", "plain"}, e.GetFailureMessages())
+}
+
+func TestResult_AddFailure_FlattensJoinedErrors(t *testing.T) {
+	nested := Result{}
+	nested.AddFailure(Failure{Path: "name", Message: "is required"})
+	first := errors.New("first")
+
+	e := Result{}
+	e.AddFailure(errors.Join(first, errors.Join(nested, errors.New("second"))))
+
+	assert.Equal(t, []string{"first", "name: is required", "second"}, e.GetFailureMessages())
+	assert.Equal(t, first, e.Failures()[0].Err)
+}
+
+func TestResult_AddFailure_KeepsErrorsWrappingSeveralErrors(t *testing.T) {
+	blocked := errors.New("blocked")
+	spam := errors.New("spam list")
+	wrapped := fmt.Errorf("email %q rejected: %w (%w)", "a@b.c", blocked, spam)
+
+	e := Result{}
+	e.AddFailure(wrapped)
+
+	assert.Equal(t, []string{`email "a@b.c" rejected: blocked (spam list)`}, e.GetFailureMessages())
+	assert.Equal(t, wrapped, e.Failures()[0].Err)
+	assert.ErrorIs(t, e, blocked)
+	assert.ErrorIs(t, e, spam)
+}
+
+// multiError is a custom error joining several errors, whose message is the
+// messages of the errors joined by newlines like the one of errors.Join
+type multiError []error
+
+func (m multiError) Error() string {
+	msgs := make([]string, len(m))
+	for i, err := range m {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (m multiError) Unwrap() []error { return m }
+
+func TestResult_AddFailure_KeepsCustomMultiErrors(t *testing.T) {
+	blocked := errors.New("blocked")
+	err := multiError{blocked, errors.New("spam list")}
+
+	e := Result{}
+	e.AddFailure(err)
+
+	assert.Equal(t, []string{"blocked\nspam list"}, e.GetFailureMessages())
+	assert.Equal(t, error(err), e.Failures()[0].Err)
+	assert.ErrorIs(t, e, blocked)
+}
+
+func TestResult_Unwrap_LetsErrorsIsAndAsFindFailures(t *testing.T) {
+	errNotFound := errors.New("not found")
+
+	v := New[string]()
+	v.AddStep(func(string) error {
+		return Failure{Path: "id", Code: "not_found", Message: "unknown id", Err: errNotFound}
+	})
+	v.AddStep(func(string) error { return errors.New("other") })
+
+	result := v.Validate("")
+
+	assert.ErrorIs(t, result, errNotFound)
+	var failure Failure
+	if assert.ErrorAs(t, result, &failure) {
+		assert.Equal(t, "id", failure.Path)
+	}
+	assert.Len(t, result.Unwrap(), 2)
+	assert.NotErrorIs(t, Result{}, errNotFound)
+}
+
+func TestResult_Unwrap_IncludesTheContextError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	v := New[string]()
+	v.AddStep(func(string) error { return nil })
+
+	result := v.ValidateContext(ctx, "")
+
+	assert.ErrorIs(t, result, context.Canceled)
+}
+
+func TestResult_Unwrap_WorksWithErrorsJoin(t *testing.T) {
+	errConflict := errors.New("conflict")
+	result := Result{}
+	result.AddFailure(errConflict)
+
+	joined := errors.Join(errors.New("request failed"), result)
+
+	assert.ErrorIs(t, joined, errConflict)
+}
@@ -0,0 +1,46 @@
+package validator
+
+// Rule checks a single value of type F. Check returns nil when the value is
+// valid, otherwise it returns the failure
+type Rule[F any] interface {
+	Check(value F) error
+}
+
+// RuleFunc adapts a function to the Rule interface
+type RuleFunc[F any] func(value F) error
+
+// Check calls f(value)
+func (f RuleFunc[F]) Check(value F) error {
+	return f(value)
+}
+
+type codedRule[F any] struct {
+	code    string
+	message string
+	params  map[string]any
+	valid   func(F) bool
+}
+
+// NewRule creates a Rule that returns a Failure with the given code, message
+// and params whenever valid returns false for the checked value
+func NewRule[F any](code, message string, params map[string]any, valid func(F) bool) Rule[F] {
+	return codedRule[F]{
+		code:    code,
+		message: message,
+		params:  params,
+		valid:   valid,
+	}
+}
+
+func (r codedRule[F]) Check(value F) error {
+	if r.valid(value) {
+		return nil
+	}
+
+	return Failure{
+		Code:    r.code,
+		Message: r.message,
+		Value:   value,
+		Params:  r.params,
+	}
+}
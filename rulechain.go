@@ -0,0 +1,221 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"unicode/utf8"
+)
+
+// Failure codes produced by the built-in rules of a rule chain
+const (
+	CodePredicate = "predicate"
+	CodeNotEmpty  = "not_empty"
+	CodeMinLength = "min_length"
+	CodeMaxLength = "max_length"
+	CodePattern   = "pattern"
+)
+
+type ruleChain[T any, F any] struct {
+	owner *validator[T]
+	step  *validationStep[T]
+	name  string
+	get   func(T) F
+	rules []Rule[F]
+}
+
+// RuleFor adds a validation step to v that checks the field returned by get
+// against the rules added to the returned rule chain. Every failure produced
+// by the chain is tagged with name as its path.
+//
+// Rules are checked in the order they were added. When the chain or the
+// validator is set to break on failure, the chain stops at the first failing
+// rule and no further steps are processed
+func RuleFor[T any, F any](v *validator[T], name string, get func(T) F) *ruleChain[T, F] {
+	chain := &ruleChain[T, F]{
+		owner: v,
+		name:  name,
+		get:   get,
+		rules: make([]Rule[F], 0),
+	}
+	chain.step = v.AddStep(chain.validate)
+	chain.step.describe = func() Description {
+		return describeRules(chain.rules).field(name, false)
+	}
+
+	return chain
+}
+
+// BreakOnFailure stops the chain at its first failing rule and forces the
+// validator to stop processing steps if the chain fails
+func (r *ruleChain[T, F]) BreakOnFailure() *ruleChain[T, F] {
+	r.step.BreakOnFailure()
+	return r
+}
+
+// When makes the step of the chain run only when predicate returns true for
+// the validated value
+func (r *ruleChain[T, F]) When(predicate func(T) bool) *ruleChain[T, F] {
+	r.step.When(predicate)
+	return r
+}
+
+// Unless makes the step of the chain run only when predicate returns false
+// for the validated value
+func (r *ruleChain[T, F]) Unless(predicate func(T) bool) *ruleChain[T, F] {
+	r.step.Unless(predicate)
+	return r
+}
+
+// Named names the step of the chain, so other steps can depend on it
+func (r *ruleChain[T, F]) Named(name string) *ruleChain[T, F] {
+	r.step.Named(name)
+	return r
+}
+
+// DependsOn makes the step of the chain run only when the named steps passed
+func (r *ruleChain[T, F]) DependsOn(names ...string) *ruleChain[T, F] {
+	r.step.DependsOn(names...)
+	return r
+}
+
+// WithSeverity reports the errors of the chain with the given severity, e.g.
+// as warnings that do not make the validation fail
+func (r *ruleChain[T, F]) WithSeverity(severity Severity) *ruleChain[T, F] {
+	r.step.WithSeverity(severity)
+	return r
+}
+
+// Check adds the given rules to the chain
+func (r *ruleChain[T, F]) Check(rules ...Rule[F]) *ruleChain[T, F] {
+	r.rules = append(r.rules, rules...)
+	return r
+}
+
+// Must adds a rule that fails with the given message when predicate returns
+// false for the field value
+func (r *ruleChain[T, F]) Must(predicate func(F) bool, message string) *ruleChain[T, F] {
+	return r.Check(NewRule(CodePredicate, message, nil, predicate))
+}
+
+// NotEmpty adds a rule that fails when the field value is the zero value of
+// its type, or a string, slice, map or channel with no elements
+func (r *ruleChain[T, F]) NotEmpty() *ruleChain[T, F] {
+	return r.Check(NewRule(CodeNotEmpty, "must not be empty", nil, func(value F) bool {
+		return !isEmpty(value)
+	}))
+}
+
+// MinLen adds a rule that fails when the length of the field value is lower
+// than min. Strings are measured in runes.
+// MinLen panics if F has no length
+func (r *ruleChain[T, F]) MinLen(min int) *ruleChain[T, F] {
+	mustHaveKind[F]("MinLen", lengthKinds...)
+
+	msg := fmt.Sprintf("length must be at least %d", min)
+	params := map[string]any{"min": min}
+	return r.Check(NewRule(CodeMinLength, msg, params, func(value F) bool {
+		n, ok := lengthOf(value)
+		return ok && n >= min
+	}))
+}
+
+// MaxLen adds a rule that fails when the length of the field value is greater
+// than max. Strings are measured in runes.
+// MaxLen panics if F has no length
+func (r *ruleChain[T, F]) MaxLen(max int) *ruleChain[T, F] {
+	mustHaveKind[F]("MaxLen", lengthKinds...)
+
+	msg := fmt.Sprintf("length must be at most %d", max)
+	params := map[string]any{"max": max}
+	return r.Check(NewRule(CodeMaxLength, msg, params, func(value F) bool {
+		n, ok := lengthOf(value)
+		return ok && n <= max
+	}))
+}
+
+// Matches adds a rule that fails when the field value does not match re.
+// Matches panics if F is not a string type
+func (r *ruleChain[T, F]) Matches(re *regexp.Regexp) *ruleChain[T, F] {
+	mustHaveKind[F]("Matches", reflect.String)
+
+	msg := fmt.Sprintf("must match pattern %s", re.String())
+	params := map[string]any{"pattern": re.String()}
+	return r.Check(NewRule(CodePattern, msg, params, func(value F) bool {
+		rv := reflect.ValueOf(value)
+		return rv.Kind() == reflect.String && re.MatchString(rv.String())
+	}))
+}
+
+func (r *ruleChain[T, F]) validate(src T) error {
+	value := r.get(src)
+	result := Result{}
+
+	for _, rule := range r.rules {
+		err := rule.Check(value)
+		if err == nil {
+			continue
+		}
+
+		result.addAtPath(r.name, value, err)
+		if (r.step.breakOnFailure || r.owner.breakOnFailure) && r.owner.fails(result) {
+			break
+		}
+	}
+
+	if result.isEmpty() {
+		return nil
+	}
+	return result
+}
+
+var lengthKinds = []reflect.Kind{
+	reflect.String, reflect.Slice, reflect.Map, reflect.Array, reflect.Chan,
+}
+
+// mustHaveKind panics if F is not of one of the given kinds. Interface types
+// are accepted, as their dynamic value is only known at validation time
+func mustHaveKind[F any](rule string, kinds ...reflect.Kind) {
+	kind := reflect.TypeOf((*F)(nil)).Elem().Kind()
+	if kind == reflect.Interface {
+		return
+	}
+
+	for _, k := range kinds {
+		if k == kind {
+			return
+		}
+	}
+
+	panic(fmt.Sprintf("validator: %s cannot be applied to values of kind %s", rule, kind))
+}
+
+func isEmpty(value any) bool {
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() {
+		return true
+	}
+
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Chan:
+		return rv.Len() == 0
+	case reflect.Pointer, reflect.Interface, reflect.Func:
+		return rv.IsNil()
+	}
+	return rv.IsZero()
+}
+
+func lengthOf(value any) (int, bool) {
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() {
+		return 0, false
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(rv.String()), true
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.Chan:
+		return rv.Len(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,178 @@
+package validator_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type customer struct {
+	Name  string
+	Code  string
+	Tags  []string
+	Age   int
+	Email *string
+}
+
+func Test_RuleFor_WhenAllRulesPass_ShouldReturnSuccess(t *testing.T) {
+	// Arrange
+	req := customer{Name: "John", Code: "AB-12", Tags: []string{"vip"}}
+
+	v := validator.New[customer]()
+	validator.RuleFor(v, "name", func(c customer) string { return c.Name }).
+		NotEmpty().
+		MaxLen(50)
+	validator.RuleFor(v, "code", func(c customer) string { return c.Code }).
+		Matches(regexp.MustCompile(`^[A-Z]{2}-\d{2}$`))
+	validator.RuleFor(v, "tags", func(c customer) []string { return c.Tags }).
+		MinLen(1)
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	assert.True(t, result.IsSuccess())
+}
+
+func Test_RuleFor_WhenRulesFail_ShouldTagFailuresWithFieldName(t *testing.T) {
+	// Arrange
+	req := customer{Name: "", Code: "abc"}
+
+	v := validator.New[customer]()
+	validator.RuleFor(v, "name", func(c customer) string { return c.Name }).
+		NotEmpty().
+		MinLen(2)
+	validator.RuleFor(v, "code", func(c customer) string { return c.Code }).
+		Matches(regexp.MustCompile(`^[A-Z]+$`))
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	assert.True(t, result.IsFailure())
+	failures := result.Failures()
+	assert.Len(t, failures, 3)
+	assert.Equal(t, "name", failures[0].Path)
+	assert.Equal(t, validator.CodeNotEmpty, failures[0].Code)
+	assert.Equal(t, "name", failures[1].Path)
+	assert.Equal(t, validator.CodeMinLength, failures[1].Code)
+	assert.Equal(t, map[string]any{"min": 2}, failures[1].Params)
+	assert.Equal(t, "code", failures[2].Path)
+	assert.Equal(t, validator.CodePattern, failures[2].Code)
+	assert.Equal(t, "abc", failures[2].Value)
+}
+
+func Test_RuleFor_WhenChainBreakOnFailureIsSet_ShouldStopAtFirstFailingRule(t *testing.T) {
+	// Arrange
+	req := customer{Name: ""}
+
+	v := validator.New[customer]()
+	validator.RuleFor(v, "name", func(c customer) string { return c.Name }).
+		NotEmpty().
+		MinLen(2).
+		BreakOnFailure()
+	validator.RuleFor(v, "age", func(c customer) int { return c.Age }).
+		Must(func(age int) bool { return age >= 18 }, "must be an adult")
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	failures := result.Failures()
+	assert.Len(t, failures, 1)
+	assert.Equal(t, validator.CodeNotEmpty, failures[0].Code)
+}
+
+func Test_RuleFor_WhenValidatorBreakOnFailureIsSet_ShouldStopAtFirstFailingRule(t *testing.T) {
+	// Arrange
+	req := customer{Name: ""}
+
+	v := validator.New[customer]().BreakOnFailure()
+	validator.RuleFor(v, "name", func(c customer) string { return c.Name }).
+		NotEmpty().
+		MinLen(2)
+	validator.RuleFor(v, "age", func(c customer) int { return c.Age }).
+		Must(func(age int) bool { return age >= 18 }, "must be an adult")
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	failures := result.Failures()
+	assert.Len(t, failures, 1)
+	assert.Equal(t, "name", failures[0].Path)
+}
+
+func Test_RuleFor_WhenCustomRuleFails_ShouldKeepOriginalError(t *testing.T) {
+	// Arrange
+	errBlocked := errors.New("blocked")
+	req := customer{Name: "spammer"}
+
+	v := validator.New[customer]()
+	validator.RuleFor(v, "name", func(c customer) string { return c.Name }).
+		Check(validator.RuleFunc[string](func(name string) error {
+			if name == "spammer" {
+				return errBlocked
+			}
+			return nil
+		}))
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	failures := result.Failures()
+	assert.Len(t, failures, 1)
+	assert.Equal(t, "name", failures[0].Path)
+	assert.Equal(t, "spammer", failures[0].Value)
+	assert.True(t, errors.Is(failures[0], errBlocked))
+}
+
+func Test_RuleFor_WhenStepsAreAddedAfterTheChain_ShouldKeepChainBreakOnFailure(t *testing.T) {
+	// Arrange
+	req := customer{}
+
+	v := validator.New[customer]()
+	chain := validator.RuleFor(v, "name", func(c customer) string { return c.Name })
+	v.AddStep(func(c customer) error { return errors.New("Error-2") })
+	chain.NotEmpty().BreakOnFailure()
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	assert.Len(t, result.Failures(), 1)
+}
+
+func Test_RuleFor_WhenRuleDoesNotApplyToFieldKind_ShouldPanic(t *testing.T) {
+	v := validator.New[customer]()
+
+	assert.Panics(t, func() {
+		validator.RuleFor(v, "age", func(c customer) int { return c.Age }).
+			MaxLen(3)
+	})
+	assert.Panics(t, func() {
+		validator.RuleFor(v, "tags", func(c customer) []string { return c.Tags }).
+			Matches(regexp.MustCompile(`.*`))
+	})
+}
+
+func Test_RuleFor_NotEmpty_WhenPointerIsNil_ShouldFail(t *testing.T) {
+	// Arrange
+	email := "john@example.com"
+
+	v := validator.New[customer]()
+	validator.RuleFor(v, "email", func(c customer) *string { return c.Email }).
+		NotEmpty()
+
+	// Act
+	withEmail := v.Validate(customer{Email: &email})
+	withoutEmail := v.Validate(customer{})
+
+	// Assert
+	assert.True(t, withEmail.IsSuccess())
+	assert.Equal(t, []string{validator.CodeNotEmpty}, withoutEmail.Codes())
+}
@@ -0,0 +1,156 @@
+package rules
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/cgxarrie-go/validator/internal/keys"
+)
+
+// Failure codes produced by the collection rules
+const (
+	CodeMinCount = validator.CodeMinCount
+	CodeMaxCount = validator.CodeMaxCount
+	CodeUnique   = "unique"
+	CodeNotNil   = "not_nil"
+	CodeSorted   = "sorted"
+)
+
+// MinCount fails when the slice has less than min elements
+func MinCount[E any](min int) validator.Rule[[]E] {
+	msg := fmt.Sprintf("must contain at least %d elements", min)
+	params := map[string]any{"min": min}
+	return validator.NewRule(CodeMinCount, msg, params, func(items []E) bool {
+		return len(items) >= min
+	})
+}
+
+// MaxCount fails when the slice has more than max elements
+func MaxCount[E any](max int) validator.Rule[[]E] {
+	msg := fmt.Sprintf("must contain at most %d elements", max)
+	params := map[string]any{"max": max}
+	return validator.NewRule(CodeMaxCount, msg, params, func(items []E) bool {
+		return len(items) <= max
+	})
+}
+
+// Unique fails for every element of the slice that is equal to a previous
+// element. The failure path is the index of the repeated element, e.g. "[3]".
+// Interface elements holding maps or slices are compared deeply
+func Unique[E comparable]() validator.Rule[[]E] {
+	return validator.DescribeRule(UniqueBy(func(e E) E { return e }), CodeUnique, nil)
+}
+
+// UniqueBy fails for every element of the slice whose key is equal to the key
+// of a previous element. The failure path is the index of the repeated
+// element, e.g. "[3]"
+func UniqueBy[E any, K comparable](key func(E) K) validator.Rule[[]E] {
+	// dynamic is true when the keys may hold values that cannot be map keys
+	kind := reflect.TypeOf((*K)(nil)).Elem().Kind()
+	dynamic := kind == reflect.Interface || kind == reflect.Struct || kind == reflect.Array
+
+	return validator.RuleFunc[[]E](func(items []E) error {
+		result := validator.Result{}
+		seen := make(map[K]int, len(items))
+		// unhashable are the indexes and keys of the elements whose key holds
+		// a map or a slice, which cannot be a map key and are compared deeply
+		var unhashable []int
+		var unhashableKeys []K
+
+		for i, item := range items {
+			k := key(item)
+
+			var first int
+			var ok bool
+			if !dynamic || keys.Hashable(reflect.ValueOf(&k).Elem()) {
+				if first, ok = seen[k]; !ok {
+					seen[k] = i
+				}
+			} else {
+				for j, other := range unhashableKeys {
+					if reflect.DeepEqual(other, k) {
+						first, ok = unhashable[j], true
+						break
+					}
+				}
+				if !ok {
+					unhashable = append(unhashable, i)
+					unhashableKeys = append(unhashableKeys, k)
+				}
+			}
+			if !ok {
+				continue
+			}
+
+			result.AddFailure(validator.Failure{
+				Path:    fmt.Sprintf("[%d]", i),
+				Code:    CodeUnique,
+				Message: fmt.Sprintf("must be unique, duplicates element %d", first),
+				Value:   item,
+				Params:  map[string]any{"index": first},
+			})
+		}
+
+		if result.IsSuccess() {
+			return nil
+		}
+		return result
+	})
+}
+
+// NotNilElements fails for every nil pointer, interface, map, slice, channel
+// or function element of the slice. The failure path is the index of the
+// nil element, e.g. "[3]"
+func NotNilElements[E any]() validator.Rule[[]E] {
+	return validator.RuleFunc[[]E](func(items []E) error {
+		result := validator.Result{}
+
+		for i, item := range items {
+			if !isNil(item) {
+				continue
+			}
+
+			result.AddFailure(validator.Failure{
+				Path:    fmt.Sprintf("[%d]", i),
+				Code:    CodeNotNil,
+				Message: "must not be nil",
+			})
+		}
+
+		if result.IsSuccess() {
+			return nil
+		}
+		return result
+	})
+}
+
+// Sorted fails when the slice is not sorted in ascending order
+func Sorted[E Ordered]() validator.Rule[[]E] {
+	return SortedBy(func(a, b E) bool { return a < b })
+}
+
+// SortedBy fails when the slice is not sorted according to less
+func SortedBy[E any](less func(a, b E) bool) validator.Rule[[]E] {
+	return validator.NewRule(CodeSorted, "must be sorted", nil, func(items []E) bool {
+		for i := 1; i < len(items); i++ {
+			if less(items[i], items[i-1]) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func isNil(value any) bool {
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() {
+		return true
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		return rv.IsNil()
+	}
+	return false
+}
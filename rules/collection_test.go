@@ -0,0 +1,94 @@
+package rules_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/cgxarrie-go/validator/rules"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectionRules_Count(t *testing.T) {
+	runRuleCases(t, []ruleCase[[]int]{
+		{name: "min count", rule: rules.MinCount[int](2), valid: [][]int{{1, 2}, {1, 2, 3}}, invalid: [][]int{nil, {1}}, code: rules.CodeMinCount, params: map[string]any{"min": 2}},
+		{name: "max count", rule: rules.MaxCount[int](2), valid: [][]int{nil, {1, 2}}, invalid: [][]int{{1, 2, 3}}, code: rules.CodeMaxCount, params: map[string]any{"max": 2}},
+		{name: "sorted", rule: rules.Sorted[int](), valid: [][]int{nil, {1}, {1, 1, 2}}, invalid: [][]int{{2, 1}, {1, 3, 2}}, code: rules.CodeSorted},
+	})
+}
+
+func TestCollectionRules_Unique(t *testing.T) {
+	rule := rules.Unique[string]()
+
+	assert.NoError(t, rule.Check([]string{"a", "b"}))
+
+	err := rule.Check([]string{"a", "b", "a", "c", "b"})
+
+	result := validator.Result{}
+	result.AddFailure(err)
+	failures := result.Failures()
+	assert.Len(t, failures, 2)
+	assert.Equal(t, "[2]", failures[0].Path)
+	assert.Equal(t, rules.CodeUnique, failures[0].Code)
+	assert.Equal(t, map[string]any{"index": 0}, failures[0].Params)
+	assert.Equal(t, "[4]", failures[1].Path)
+	assert.Equal(t, "b", failures[1].Value)
+}
+
+func TestCollectionRules_UniqueOfInterfaces(t *testing.T) {
+	rule := rules.Unique[any]()
+
+	assert.NoError(t, rule.Check([]any{map[string]any{"a": 1}, map[string]any{"a": 2}, []any{1}, 1}))
+
+	err := rule.Check([]any{map[string]any{"a": 1}, []any{1}, 1, map[string]any{"a": 1}, []any{1}, 1})
+
+	result := validator.Result{}
+	result.AddFailure(err)
+	failures := result.Failures()
+	if assert.Len(t, failures, 3) {
+		assert.Equal(t, "[3]", failures[0].Path)
+		assert.Equal(t, "[4]", failures[1].Path)
+		assert.Equal(t, map[string]any{"index": 1}, failures[1].Params)
+		assert.Equal(t, "[5]", failures[2].Path)
+	}
+}
+
+func TestCollectionRules_UniqueBy(t *testing.T) {
+	rule := rules.UniqueBy(strings.ToLower)
+
+	assert.NoError(t, rule.Check([]string{"a", "b"}))
+	assert.Error(t, rule.Check([]string{"a", "A"}))
+}
+
+func TestCollectionRules_NotNilElements(t *testing.T) {
+	one := 1
+	rule := rules.NotNilElements[*int]()
+
+	assert.NoError(t, rule.Check([]*int{&one}))
+
+	result := validator.Result{}
+	result.AddFailure(rule.Check([]*int{&one, nil}))
+	assert.Len(t, result.Failures(), 1)
+	assert.Equal(t, "[1]", result.Failures()[0].Path)
+	assert.Equal(t, rules.CodeNotNil, result.Failures()[0].Code)
+}
+
+func TestCollectionRules_WithForEach(t *testing.T) {
+	type basket struct {
+		Items []string
+	}
+
+	v := validator.New[basket]()
+	validator.ForEach(v, "items", func(b basket) []string { return b.Items }).
+		CheckAll(rules.MaxCount[string](3), rules.Unique[string]()).
+		Check(rules.NotBlank())
+
+	result := v.Validate(basket{Items: []string{"apple", " ", "apple"}})
+
+	failures := result.Failures()
+	assert.Len(t, failures, 2)
+	assert.Equal(t, "items[2]", failures[0].Path)
+	assert.Equal(t, rules.CodeUnique, failures[0].Code)
+	assert.Equal(t, "items[1]", failures[1].Path)
+	assert.Equal(t, rules.CodeNotBlank, failures[1].Code)
+}
@@ -0,0 +1,272 @@
+package rules
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cgxarrie-go/validator"
+)
+
+// Failure codes produced by the format rules
+const (
+	CodeEmail        = "email"
+	CodeURL          = "url"
+	CodeURLReference = "url_reference"
+	CodeUUID         = "uuid"
+	CodeIP           = "ip"
+	CodeIPv4         = "ipv4"
+	CodeIPv6         = "ipv6"
+	CodeCIDR         = "cidr"
+	CodeHostname     = "hostname"
+	CodeFQDN         = "fqdn"
+	CodeMAC          = "mac"
+	CodePort         = "port"
+)
+
+// Message keys of the format rules whose message depends on their
+// parameters. The other rules use their code as message key
+const (
+	// KeyURLSchemes is the message key of URL with schemes
+	KeyURLSchemes = "url_schemes"
+	// KeyUUIDVersions is the message key of UUID with versions
+	KeyUUIDVersions = "uuid_versions"
+)
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Email fails when the string is not a bare email address such as
+// "john@example.com". Display names and angle brackets are not accepted,
+// and the domain must be a valid hostname or an IP literal
+func Email() validator.Rule[string] {
+	return validator.NewRule(CodeEmail, "must be a valid email address", nil, isEmail)
+}
+
+// URL fails when the string is not an absolute URL. When schemes are given,
+// the URL scheme must be one of them
+func URL(schemes ...string) validator.Rule[string] {
+	msg := "must be a valid URL"
+	var params map[string]any
+	if len(schemes) > 0 {
+		msg = fmt.Sprintf("must be a valid URL with scheme [%s]", strings.Join(schemes, ", "))
+		params = map[string]any{"schemes": schemes}
+	}
+
+	rule := validator.NewRule(CodeURL, msg, params, func(s string) bool {
+		u, err := url.Parse(s)
+		if err != nil || u.Scheme == "" || (u.Host == "" && u.Opaque == "") {
+			return false
+		}
+
+		if len(schemes) == 0 {
+			return true
+		}
+
+		for _, scheme := range schemes {
+			if strings.EqualFold(u.Scheme, scheme) {
+				return true
+			}
+		}
+		return false
+	})
+
+	if len(schemes) > 0 {
+		return withKey(rule, KeyURLSchemes, CodeURL, params)
+	}
+	return rule
+}
+
+// URLReference fails when the string is neither an absolute nor a relative
+// URL
+func URLReference() validator.Rule[string] {
+	return validator.NewRule(CodeURLReference, "must be a valid URL reference", nil, func(s string) bool {
+		if strings.TrimSpace(s) == "" {
+			return false
+		}
+
+		_, err := url.Parse(s)
+		return err == nil
+	})
+}
+
+// UUID fails when the string is not an RFC 4122 / RFC 9562 UUID of versions
+// 1 to 8. When versions are given, the UUID must be of one of them
+func UUID(versions ...int) validator.Rule[string] {
+	msg := "must be a valid UUID"
+	var params map[string]any
+	if len(versions) > 0 {
+		msg = fmt.Sprintf("must be a valid UUID of version %v", versions)
+		params = map[string]any{"versions": versions}
+	}
+
+	rule := validator.NewRule(CodeUUID, msg, params, func(s string) bool {
+		if !uuidRegexp.MatchString(s) {
+			return false
+		}
+
+		version := int(s[14] - '0')
+		if version < 1 || version > 8 {
+			return false
+		}
+
+		if !strings.ContainsRune("89abAB", rune(s[19])) {
+			return false
+		}
+
+		if len(versions) == 0 {
+			return true
+		}
+
+		for _, v := range versions {
+			if v == version {
+				return true
+			}
+		}
+		return false
+	})
+
+	if len(versions) > 0 {
+		return withKey(rule, KeyUUIDVersions, CodeUUID, params)
+	}
+	return rule
+}
+
+// IP fails when the string is not an IPv4 or IPv6 address
+func IP() validator.Rule[string] {
+	return validator.NewRule(CodeIP, "must be a valid IP address", nil, func(s string) bool {
+		return net.ParseIP(s) != nil
+	})
+}
+
+// IPv4 fails when the string is not an IPv4 address in dotted decimal form
+func IPv4() validator.Rule[string] {
+	return validator.NewRule(CodeIPv4, "must be a valid IPv4 address", nil, func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() != nil && !strings.Contains(s, ":")
+	})
+}
+
+// IPv6 fails when the string is not an IPv6 address
+func IPv6() validator.Rule[string] {
+	return validator.NewRule(CodeIPv6, "must be a valid IPv6 address", nil, func(s string) bool {
+		return net.ParseIP(s) != nil && strings.Contains(s, ":")
+	})
+}
+
+// CIDR fails when the string is not an IP network in CIDR notation, such as
+// "192.168.0.0/16" or "2001:db8::/32"
+func CIDR() validator.Rule[string] {
+	return validator.NewRule(CodeCIDR, "must be a valid CIDR notation", nil, func(s string) bool {
+		_, _, err := net.ParseCIDR(s)
+		return err == nil
+	})
+}
+
+// Hostname fails when the string is not a hostname as defined by RFC 1123
+func Hostname() validator.Rule[string] {
+	return validator.NewRule(CodeHostname, "must be a valid hostname", nil, isHostname)
+}
+
+// FQDN fails when the string is not a fully qualified domain name: a valid
+// hostname with at least two labels and a non numeric top level domain.
+// A trailing dot is accepted
+func FQDN() validator.Rule[string] {
+	msg := "must be a fully qualified domain name"
+	return validator.NewRule(CodeFQDN, msg, nil, func(s string) bool {
+		s = strings.TrimSuffix(s, ".")
+		if !isHostname(s) {
+			return false
+		}
+
+		labels := strings.Split(s, ".")
+		if len(labels) < 2 {
+			return false
+		}
+
+		_, err := strconv.Atoi(labels[len(labels)-1])
+		return err != nil
+	})
+}
+
+// MAC fails when the string is not an IEEE 802 MAC-48, EUI-48, EUI-64 or
+// 20-octet IP over InfiniBand link-layer address
+func MAC() validator.Rule[string] {
+	return validator.NewRule(CodeMAC, "must be a valid MAC address", nil, func(s string) bool {
+		_, err := net.ParseMAC(s)
+		return err == nil
+	})
+}
+
+// Port fails when the number is not a valid TCP/UDP port, from 1 to 65535
+func Port() validator.Rule[int] {
+	params := map[string]any{"min": 1, "max": 65535}
+	return validator.NewRule(CodePort, "must be a valid port number", params, func(n int) bool {
+		return n >= 1 && n <= 65535
+	})
+}
+
+// withKey sets key as the message key of the failures of rule, which is
+// described with code and params
+func withKey[F any](rule validator.Rule[F], key, code string, params map[string]any) validator.Rule[F] {
+	keyed := validator.RuleFunc[F](func(value F) error {
+		err := rule.Check(value)
+		if f, ok := err.(validator.Failure); ok {
+			f.Key = key
+			return f
+		}
+		return err
+	})
+
+	return validator.DescribeRule[F](keyed, code, params)
+}
+
+func isEmail(s string) bool {
+	if len(s) > 254 {
+		return false
+	}
+
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Name != "" || addr.Address != s {
+		return false
+	}
+
+	at := strings.LastIndex(s, "@")
+	local, domain := s[:at], s[at+1:]
+	if len(local) > 64 {
+		return false
+	}
+
+	if strings.HasPrefix(domain, "[") && strings.HasSuffix(domain, "]") {
+		return net.ParseIP(strings.TrimPrefix(domain[1:len(domain)-1], "IPv6:")) != nil
+	}
+
+	return isHostname(domain)
+}
+
+func isHostname(s string) bool {
+	if s == "" || len(s) > 253 {
+		return false
+	}
+
+	for _, label := range strings.Split(s, ".") {
+		if len(label) == 0 || len(label) > 63 {
+			return false
+		}
+
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+
+		for _, r := range label {
+			isAlnum := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+			if !isAlnum && r != '-' {
+				return false
+			}
+		}
+	}
+	return true
+}
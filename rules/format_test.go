@@ -0,0 +1,152 @@
+package rules_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/cgxarrie-go/validator/rules"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    validator.Rule[string]
+		valid   []string
+		invalid []string
+		code    string
+	}{
+		{
+			name:    "email",
+			rule:    rules.Email(),
+			valid:   []string{"john@example.com", "john.doe+tag@mail.example.co.uk", "a@localhost", "john@[192.168.0.1]", "john@[IPv6:2001:db8::1]"},
+			invalid: []string{"", "john", "john@", "@example.com", "John <john@example.com>", "john@exa_mple.com", "john@-example.com", strings.Repeat("a", 65) + "@example.com"},
+			code:    rules.CodeEmail,
+		},
+		{
+			name:    "url",
+			rule:    rules.URL(),
+			valid:   []string{"https://example.com", "http://example.com:8080/path?q=1#frag", "ftp://files.example.com", "mailto:john@example.com"},
+			invalid: []string{"", "example.com", "/relative/path", "http://", "://example.com"},
+			code:    rules.CodeURL,
+		},
+		{
+			name:    "url with schemes",
+			rule:    rules.URL("https"),
+			valid:   []string{"https://example.com", "HTTPS://example.com"},
+			invalid: []string{"http://example.com", "ftp://example.com"},
+			code:    rules.CodeURL,
+		},
+		{
+			name:    "url reference",
+			rule:    rules.URLReference(),
+			valid:   []string{"https://example.com", "/relative/path", "path?q=1", "#frag"},
+			invalid: []string{"", " ", "http://[::1", "%zz"},
+			code:    rules.CodeURLReference,
+		},
+		{
+			name:    "uuid",
+			rule:    rules.UUID(),
+			valid:   []string{"123e4567-e89b-12d3-a456-426614174000", "550E8400-E29B-41D4-A716-446655440000", "01890a5d-ac96-774b-bcce-b302099a8057", "0189f7e0-0000-8000-8000-000000000000"},
+			invalid: []string{"", "00000000-0000-0000-0000-000000000000", "123e4567-e89b-92d3-a456-426614174000", "123e4567-e89b-12d3-c456-426614174000", "123e4567e89b12d3a456426614174000", "123e4567-e89b-12d3-a456-42661417400g"},
+			code:    rules.CodeUUID,
+		},
+		{
+			name:    "uuid with versions",
+			rule:    rules.UUID(4, 7),
+			valid:   []string{"550e8400-e29b-41d4-a716-446655440000", "01890a5d-ac96-774b-bcce-b302099a8057"},
+			invalid: []string{"123e4567-e89b-12d3-a456-426614174000"},
+			code:    rules.CodeUUID,
+		},
+		{
+			name:    "ip",
+			rule:    rules.IP(),
+			valid:   []string{"192.168.0.1", "::1", "2001:db8::68"},
+			invalid: []string{"", "256.0.0.1", "192.168.0", "example.com"},
+			code:    rules.CodeIP,
+		},
+		{
+			name:    "ipv4",
+			rule:    rules.IPv4(),
+			valid:   []string{"192.168.0.1", "0.0.0.0"},
+			invalid: []string{"", "::1", "::ffff:192.168.0.1", "192.168.0.256"},
+			code:    rules.CodeIPv4,
+		},
+		{
+			name:    "ipv6",
+			rule:    rules.IPv6(),
+			valid:   []string{"::1", "2001:db8::68", "::ffff:192.168.0.1"},
+			invalid: []string{"", "192.168.0.1", "2001:db8:::68"},
+			code:    rules.CodeIPv6,
+		},
+		{
+			name:    "cidr",
+			rule:    rules.CIDR(),
+			valid:   []string{"192.168.0.0/16", "2001:db8::/32"},
+			invalid: []string{"", "192.168.0.0", "192.168.0.0/33"},
+			code:    rules.CodeCIDR,
+		},
+		{
+			name:    "hostname",
+			rule:    rules.Hostname(),
+			valid:   []string{"localhost", "example.com", "1example.com", "my-host.example.com"},
+			invalid: []string{"", "-example.com", "example-.com", "exa_mple.com", "example..com", strings.Repeat("a", 64) + ".com"},
+			code:    rules.CodeHostname,
+		},
+		{
+			name:    "fqdn",
+			rule:    rules.FQDN(),
+			valid:   []string{"example.com", "www.example.com."},
+			invalid: []string{"", "localhost", "example.123", "exa_mple.com"},
+			code:    rules.CodeFQDN,
+		},
+		{
+			name:    "mac",
+			rule:    rules.MAC(),
+			valid:   []string{"00:00:5e:00:53:01", "00-00-5E-00-53-01", "0000.5e00.5301", "02:00:5e:10:00:00:00:01"},
+			invalid: []string{"", "00:00:5e:00:53", "00:00:5e:00:53:zz"},
+			code:    rules.CodeMAC,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, s := range tt.valid {
+				assert.NoError(t, tt.rule.Check(s), "value %q", s)
+			}
+
+			for _, s := range tt.invalid {
+				err := tt.rule.Check(s)
+
+				failure, ok := err.(validator.Failure)
+				if assert.True(t, ok, "value %q", s) {
+					assert.Equal(t, tt.code, failure.Code)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatRules_Port(t *testing.T) {
+	rule := rules.Port()
+
+	assert.NoError(t, rule.Check(1))
+	assert.NoError(t, rule.Check(8080))
+	assert.NoError(t, rule.Check(65535))
+	assert.Error(t, rule.Check(0))
+	assert.Error(t, rule.Check(65536))
+	assert.Error(t, rule.Check(-1))
+}
+
+func TestFormatRules_WithAddStep(t *testing.T) {
+	type contact struct {
+		Email string
+	}
+
+	v := validator.New[contact]()
+	v.AddStep(func(c contact) error { return rules.Email().Check(c.Email) })
+
+	result := v.Validate(contact{Email: "not-an-email"})
+
+	assert.Equal(t, []string{rules.CodeEmail}, result.Codes())
+}
@@ -0,0 +1,214 @@
+package rules
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/cgxarrie-go/validator"
+)
+
+// Failure codes produced by the numeric and ordered rules
+const (
+	CodeMin              = "min"
+	CodeMax              = "max"
+	CodeBetween          = "between"
+	CodeExclusiveBetween = "exclusive_between"
+	CodeGreaterThan      = "greater_than"
+	CodeLessThan         = "less_than"
+	CodeEqual            = "equal"
+	CodeNotEqual         = "not_equal"
+	CodePositive         = "positive"
+	CodeNegative         = "negative"
+	CodeNonZero          = "non_zero"
+	CodeMultipleOf       = "multiple_of"
+	CodeFinite           = "finite"
+	CodeNotNaN           = "not_nan"
+	CodeDecimalPlaces    = "decimal_places"
+)
+
+// Signed is a constraint that permits any signed integer type
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is a constraint that permits any unsigned integer type
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer is a constraint that permits any integer type
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float is a constraint that permits any floating-point type
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is a constraint that permits any integer or floating-point type
+type Number interface {
+	Integer | Float
+}
+
+// Ordered is a constraint that permits any type supporting the < <= >= >
+// operators
+type Ordered interface {
+	Integer | Float | ~string
+}
+
+// Min fails when the value is lower than min
+func Min[N Ordered](min N) validator.Rule[N] {
+	msg := fmt.Sprintf("must be greater than or equal to %v", min)
+	params := map[string]any{"min": min}
+	return validator.NewRule(CodeMin, msg, params, func(v N) bool {
+		return v >= min
+	})
+}
+
+// Max fails when the value is greater than max
+func Max[N Ordered](max N) validator.Rule[N] {
+	msg := fmt.Sprintf("must be less than or equal to %v", max)
+	params := map[string]any{"max": max}
+	return validator.NewRule(CodeMax, msg, params, func(v N) bool {
+		return v <= max
+	})
+}
+
+// Between fails when the value is not between min and max, both included
+func Between[N Ordered](min, max N) validator.Rule[N] {
+	msg := fmt.Sprintf("must be between %v and %v", min, max)
+	params := map[string]any{"min": min, "max": max}
+	return validator.NewRule(CodeBetween, msg, params, func(v N) bool {
+		return v >= min && v <= max
+	})
+}
+
+// ExclusiveBetween fails when the value is not between min and max, both
+// excluded
+func ExclusiveBetween[N Ordered](min, max N) validator.Rule[N] {
+	msg := fmt.Sprintf("must be greater than %v and less than %v", min, max)
+	params := map[string]any{"min": min, "max": max}
+	return validator.NewRule(CodeExclusiveBetween, msg, params, func(v N) bool {
+		return v > min && v < max
+	})
+}
+
+// GreaterThan fails when the value is not greater than other
+func GreaterThan[N Ordered](other N) validator.Rule[N] {
+	msg := fmt.Sprintf("must be greater than %v", other)
+	params := map[string]any{"value": other}
+	return validator.NewRule(CodeGreaterThan, msg, params, func(v N) bool {
+		return v > other
+	})
+}
+
+// LessThan fails when the value is not less than other
+func LessThan[N Ordered](other N) validator.Rule[N] {
+	msg := fmt.Sprintf("must be less than %v", other)
+	params := map[string]any{"value": other}
+	return validator.NewRule(CodeLessThan, msg, params, func(v N) bool {
+		return v < other
+	})
+}
+
+// Equal fails when the value is not equal to other
+func Equal[V comparable](other V) validator.Rule[V] {
+	msg := fmt.Sprintf("must be equal to %v", other)
+	params := map[string]any{"value": other}
+	return validator.NewRule(CodeEqual, msg, params, func(v V) bool {
+		return v == other
+	})
+}
+
+// NotEqual fails when the value is equal to other
+func NotEqual[V comparable](other V) validator.Rule[V] {
+	msg := fmt.Sprintf("must not be equal to %v", other)
+	params := map[string]any{"value": other}
+	return validator.NewRule(CodeNotEqual, msg, params, func(v V) bool {
+		return v != other
+	})
+}
+
+// Positive fails when the value is not greater than zero
+func Positive[N Number]() validator.Rule[N] {
+	return validator.NewRule(CodePositive, "must be positive", nil, func(v N) bool {
+		return v > 0
+	})
+}
+
+// Negative fails when the value is not lower than zero
+func Negative[N Number]() validator.Rule[N] {
+	return validator.NewRule(CodeNegative, "must be negative", nil, func(v N) bool {
+		return v < 0
+	})
+}
+
+// NonZero fails when the value is zero
+func NonZero[N Number]() validator.Rule[N] {
+	return validator.NewRule(CodeNonZero, "must not be zero", nil, func(v N) bool {
+		return v != 0
+	})
+}
+
+// MultipleOf fails when the value is not a multiple of n.
+// MultipleOf panics if n is zero
+func MultipleOf[N Integer](n N) validator.Rule[N] {
+	if n == 0 {
+		panic("rules: MultipleOf requires a non zero value")
+	}
+
+	msg := fmt.Sprintf("must be a multiple of %v", n)
+	params := map[string]any{"value": n}
+	return validator.NewRule(CodeMultipleOf, msg, params, func(v N) bool {
+		return v%n == 0
+	})
+}
+
+// Finite fails when the value is NaN or infinite
+func Finite[F Float]() validator.Rule[F] {
+	return validator.NewRule(CodeFinite, "must be a finite number", nil, func(v F) bool {
+		f := float64(v)
+		return !math.IsNaN(f) && !math.IsInf(f, 0)
+	})
+}
+
+// NotNaN fails when the value is NaN
+func NotNaN[F Float]() validator.Rule[F] {
+	return validator.NewRule(CodeNotNaN, "must be a number", nil, func(v F) bool {
+		return !math.IsNaN(float64(v))
+	})
+}
+
+// MaxDecimalPlaces fails when the shortest decimal representation of the
+// value has more than places decimal places, e.g. 1.125 has 3 decimal places.
+// NaN and infinite values fail
+func MaxDecimalPlaces[F Float](places int) validator.Rule[F] {
+	msg := fmt.Sprintf("must have at most %d decimal places", places)
+	params := map[string]any{"places": places}
+	return validator.NewRule(CodeDecimalPlaces, msg, params, func(v F) bool {
+		return decimalPlaces(v) <= places
+	})
+}
+
+func decimalPlaces[F Float](v F) int {
+	f := float64(v)
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return math.MaxInt
+	}
+
+	bitSize := 64
+	if reflect.TypeOf(v).Kind() == reflect.Float32 {
+		bitSize = 32
+	}
+
+	s := strconv.FormatFloat(f, 'f', -1, bitSize)
+	i := strings.IndexByte(s, '.')
+	if i < 0 {
+		return 0
+	}
+	return len(s) - i - 1
+}
@@ -0,0 +1,103 @@
+package rules_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/cgxarrie-go/validator/rules"
+	"github.com/stretchr/testify/assert"
+)
+
+type ruleCase[N any] struct {
+	name    string
+	rule    validator.Rule[N]
+	valid   []N
+	invalid []N
+	code    string
+	params  map[string]any
+}
+
+func runRuleCases[N any](t *testing.T, tests []ruleCase[N]) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, v := range tt.valid {
+				assert.NoError(t, tt.rule.Check(v), "value %v", v)
+			}
+
+			for _, v := range tt.invalid {
+				err := tt.rule.Check(v)
+
+				failure, ok := err.(validator.Failure)
+				if assert.True(t, ok, "value %v", v) {
+					assert.Equal(t, tt.code, failure.Code)
+					assert.Equal(t, tt.params, failure.Params)
+				}
+			}
+		})
+	}
+}
+
+func TestOrderedRules_Int(t *testing.T) {
+	runRuleCases(t, []ruleCase[int]{
+		{name: "min", rule: rules.Min(18), valid: []int{18, 19}, invalid: []int{17, -18}, code: rules.CodeMin, params: map[string]any{"min": 18}},
+		{name: "max", rule: rules.Max(10), valid: []int{10, -1}, invalid: []int{11}, code: rules.CodeMax, params: map[string]any{"max": 10}},
+		{name: "between", rule: rules.Between(1, 3), valid: []int{1, 2, 3}, invalid: []int{0, 4}, code: rules.CodeBetween, params: map[string]any{"min": 1, "max": 3}},
+		{name: "exclusive between", rule: rules.ExclusiveBetween(1, 3), valid: []int{2}, invalid: []int{1, 3}, code: rules.CodeExclusiveBetween, params: map[string]any{"min": 1, "max": 3}},
+		{name: "greater than", rule: rules.GreaterThan(5), valid: []int{6}, invalid: []int{5, 4}, code: rules.CodeGreaterThan, params: map[string]any{"value": 5}},
+		{name: "less than", rule: rules.LessThan(5), valid: []int{4}, invalid: []int{5, 6}, code: rules.CodeLessThan, params: map[string]any{"value": 5}},
+		{name: "equal", rule: rules.Equal(5), valid: []int{5}, invalid: []int{4}, code: rules.CodeEqual, params: map[string]any{"value": 5}},
+		{name: "not equal", rule: rules.NotEqual(5), valid: []int{4}, invalid: []int{5}, code: rules.CodeNotEqual, params: map[string]any{"value": 5}},
+		{name: "positive", rule: rules.Positive[int](), valid: []int{1}, invalid: []int{0, -1}, code: rules.CodePositive},
+		{name: "negative", rule: rules.Negative[int](), valid: []int{-1}, invalid: []int{0, 1}, code: rules.CodeNegative},
+		{name: "non zero", rule: rules.NonZero[int](), valid: []int{-1, 1}, invalid: []int{0}, code: rules.CodeNonZero},
+		{name: "multiple of", rule: rules.MultipleOf(5), valid: []int{0, 5, -10}, invalid: []int{1, 11}, code: rules.CodeMultipleOf, params: map[string]any{"value": 5}},
+	})
+}
+
+func TestOrderedRules_Float(t *testing.T) {
+	nan := math.NaN()
+	inf := math.Inf(1)
+
+	runRuleCases(t, []ruleCase[float64]{
+		{name: "min", rule: rules.Min(0.5), valid: []float64{0.5, 1}, invalid: []float64{0.49, nan}, code: rules.CodeMin, params: map[string]any{"min": 0.5}},
+		{name: "finite", rule: rules.Finite[float64](), valid: []float64{0, 1.5}, invalid: []float64{nan, inf, -inf}, code: rules.CodeFinite},
+		{name: "not nan", rule: rules.NotNaN[float64](), valid: []float64{0, inf}, invalid: []float64{nan}, code: rules.CodeNotNaN},
+		{name: "max decimal places", rule: rules.MaxDecimalPlaces[float64](2), valid: []float64{1, 1.5, 1.25, 100}, invalid: []float64{1.125, 0.001, nan, inf}, code: rules.CodeDecimalPlaces, params: map[string]any{"places": 2}},
+	})
+}
+
+func TestOrderedRules_Float32DecimalPlaces(t *testing.T) {
+	rule := rules.MaxDecimalPlaces[float32](1)
+
+	assert.NoError(t, rule.Check(0.1))
+	assert.Error(t, rule.Check(0.15))
+}
+
+func TestOrderedRules_String(t *testing.T) {
+	runRuleCases(t, []ruleCase[string]{
+		{name: "between", rule: rules.Between("b", "d"), valid: []string{"b", "c", "d"}, invalid: []string{"a", "e"}, code: rules.CodeBetween, params: map[string]any{"min": "b", "max": "d"}},
+	})
+}
+
+func TestOrderedRules_MultipleOfZero_ShouldPanic(t *testing.T) {
+	assert.Panics(t, func() { rules.MultipleOf(0) })
+}
+
+func TestOrderedRules_WithRuleFor(t *testing.T) {
+	type order struct {
+		Quantity int64
+		Price    float64
+	}
+
+	v := validator.New[order]()
+	validator.RuleFor(v, "quantity", func(o order) int64 { return o.Quantity }).
+		Check(rules.Positive[int64](), rules.Max[int64](100))
+	validator.RuleFor(v, "price", func(o order) float64 { return o.Price }).
+		Check(rules.Finite[float64](), rules.MaxDecimalPlaces[float64](2))
+
+	result := v.Validate(order{Quantity: 200, Price: 9.999})
+
+	assert.Equal(t, []string{rules.CodeMax, rules.CodeDecimalPlaces}, result.Codes())
+	assert.Equal(t, int64(100), result.FailuresFor("quantity")[0].Params["max"])
+}
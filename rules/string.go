@@ -0,0 +1,205 @@
+// Package rules provides a library of reusable rules for the validator
+// package. Every rule produces a validator.Failure with a stable code, a
+// default message and the rule parameters, so that results look the same
+// across services.
+//
+// Rules are meant to be used with validator.RuleFor:
+//
+//	validator.RuleFor(v, "name", func(c Customer) string { return c.Name }).
+//		Check(rules.NotBlank(), rules.MaxLength(50), rules.Trimmed())
+//
+// Character class rules such as Alpha or Lowercase accept the empty string.
+// Combine them with NotEmpty or NotBlank when the value is required.
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cgxarrie-go/validator"
+)
+
+// Failure codes produced by the string rules
+const (
+	CodeNotEmpty     = validator.CodeNotEmpty
+	CodeNotBlank     = "not_blank"
+	CodeMinLength    = validator.CodeMinLength
+	CodeMaxLength    = validator.CodeMaxLength
+	CodeLength       = "length"
+	CodePattern      = validator.CodePattern
+	CodePrefix       = "prefix"
+	CodeSuffix       = "suffix"
+	CodeContains     = "contains"
+	CodeOneOf        = "one_of"
+	CodeAlpha        = "alpha"
+	CodeAlphanumeric = "alphanumeric"
+	CodeASCII        = "ascii"
+	CodePrintable    = "printable"
+	CodeLowercase    = "lowercase"
+	CodeUppercase    = "uppercase"
+	CodeTrimmed      = "trimmed"
+	CodeUTF8         = "utf8"
+)
+
+// NotEmpty fails when the string is empty
+func NotEmpty() validator.Rule[string] {
+	return validator.NewRule(CodeNotEmpty, "must not be empty", nil, func(s string) bool {
+		return s != ""
+	})
+}
+
+// NotBlank fails when the string is empty or contains only whitespaces
+func NotBlank() validator.Rule[string] {
+	return validator.NewRule(CodeNotBlank, "must not be blank", nil, func(s string) bool {
+		return strings.TrimSpace(s) != ""
+	})
+}
+
+// MinLength fails when the string has less than min runes
+func MinLength(min int) validator.Rule[string] {
+	msg := fmt.Sprintf("length must be at least %d", min)
+	params := map[string]any{"min": min}
+	return validator.NewRule(CodeMinLength, msg, params, func(s string) bool {
+		return utf8.RuneCountInString(s) >= min
+	})
+}
+
+// MaxLength fails when the string has more than max runes
+func MaxLength(max int) validator.Rule[string] {
+	msg := fmt.Sprintf("length must be at most %d", max)
+	params := map[string]any{"max": max}
+	return validator.NewRule(CodeMaxLength, msg, params, func(s string) bool {
+		return utf8.RuneCountInString(s) <= max
+	})
+}
+
+// Length fails when the string does not have exactly n runes
+func Length(n int) validator.Rule[string] {
+	msg := fmt.Sprintf("length must be exactly %d", n)
+	params := map[string]any{"length": n}
+	return validator.NewRule(CodeLength, msg, params, func(s string) bool {
+		return utf8.RuneCountInString(s) == n
+	})
+}
+
+// Matches fails when the string does not match re
+func Matches(re *regexp.Regexp) validator.Rule[string] {
+	msg := fmt.Sprintf("must match pattern %s", re.String())
+	params := map[string]any{"pattern": re.String()}
+	return validator.NewRule(CodePattern, msg, params, re.MatchString)
+}
+
+// HasPrefix fails when the string does not start with prefix
+func HasPrefix(prefix string) validator.Rule[string] {
+	msg := fmt.Sprintf("must start with %q", prefix)
+	params := map[string]any{"prefix": prefix}
+	return validator.NewRule(CodePrefix, msg, params, func(s string) bool {
+		return strings.HasPrefix(s, prefix)
+	})
+}
+
+// HasSuffix fails when the string does not end with suffix
+func HasSuffix(suffix string) validator.Rule[string] {
+	msg := fmt.Sprintf("must end with %q", suffix)
+	params := map[string]any{"suffix": suffix}
+	return validator.NewRule(CodeSuffix, msg, params, func(s string) bool {
+		return strings.HasSuffix(s, suffix)
+	})
+}
+
+// Contains fails when the string does not contain substr
+func Contains(substr string) validator.Rule[string] {
+	msg := fmt.Sprintf("must contain %q", substr)
+	params := map[string]any{"substring": substr}
+	return validator.NewRule(CodeContains, msg, params, func(s string) bool {
+		return strings.Contains(s, substr)
+	})
+}
+
+// OneOf fails when the string is not one of the given values
+func OneOf(values ...string) validator.Rule[string] {
+	msg := fmt.Sprintf("must be one of [%s]", strings.Join(values, ", "))
+	params := map[string]any{"values": values}
+	return validator.NewRule(CodeOneOf, msg, params, func(s string) bool {
+		for _, v := range values {
+			if s == v {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Alpha fails when the string contains anything but letters
+func Alpha() validator.Rule[string] {
+	return validator.NewRule(CodeAlpha, "must contain only letters", nil, func(s string) bool {
+		return all(s, unicode.IsLetter)
+	})
+}
+
+// Alphanumeric fails when the string contains anything but letters and digits
+func Alphanumeric() validator.Rule[string] {
+	msg := "must contain only letters and digits"
+	return validator.NewRule(CodeAlphanumeric, msg, nil, func(s string) bool {
+		return all(s, func(r rune) bool {
+			return unicode.IsLetter(r) || unicode.IsDigit(r)
+		})
+	})
+}
+
+// ASCII fails when the string contains non ASCII characters
+func ASCII() validator.Rule[string] {
+	msg := "must contain only ASCII characters"
+	return validator.NewRule(CodeASCII, msg, nil, func(s string) bool {
+		return all(s, func(r rune) bool {
+			return r <= unicode.MaxASCII
+		})
+	})
+}
+
+// Printable fails when the string contains non printable characters
+func Printable() validator.Rule[string] {
+	msg := "must contain only printable characters"
+	return validator.NewRule(CodePrintable, msg, nil, func(s string) bool {
+		return all(s, unicode.IsPrint)
+	})
+}
+
+// Lowercase fails when the string contains uppercase letters
+func Lowercase() validator.Rule[string] {
+	return validator.NewRule(CodeLowercase, "must be lowercase", nil, func(s string) bool {
+		return s == strings.ToLower(s)
+	})
+}
+
+// Uppercase fails when the string contains lowercase letters
+func Uppercase() validator.Rule[string] {
+	return validator.NewRule(CodeUppercase, "must be uppercase", nil, func(s string) bool {
+		return s == strings.ToUpper(s)
+	})
+}
+
+// Trimmed fails when the string has leading or trailing whitespaces
+func Trimmed() validator.Rule[string] {
+	msg := "must not have leading or trailing whitespaces"
+	return validator.NewRule(CodeTrimmed, msg, nil, func(s string) bool {
+		return s == strings.TrimSpace(s)
+	})
+}
+
+// ValidUTF8 fails when the string is not valid UTF-8
+func ValidUTF8() validator.Rule[string] {
+	return validator.NewRule(CodeUTF8, "must be valid UTF-8", nil, utf8.ValidString)
+}
+
+func all(s string, fn func(rune) bool) bool {
+	for _, r := range s {
+		if !fn(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package rules_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/cgxarrie-go/validator/rules"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    validator.Rule[string]
+		valid   []string
+		invalid []string
+		code    string
+	}{
+		{name: "not empty", rule: rules.NotEmpty(), valid: []string{"a", " "}, invalid: []string{""}, code: rules.CodeNotEmpty},
+		{name: "not blank", rule: rules.NotBlank(), valid: []string{"a", " a "}, invalid: []string{"", " \t\n"}, code: rules.CodeNotBlank},
+		{name: "min length", rule: rules.MinLength(3), valid: []string{"abc", "ñañ", "abcd"}, invalid: []string{"", "ab", "ñ"}, code: rules.CodeMinLength},
+		{name: "max length", rule: rules.MaxLength(3), valid: []string{"", "abc", "ñañ"}, invalid: []string{"abcd", "ññññ"}, code: rules.CodeMaxLength},
+		{name: "length", rule: rules.Length(2), valid: []string{"ab", "ññ"}, invalid: []string{"a", "abc"}, code: rules.CodeLength},
+		{name: "matches", rule: rules.Matches(regexp.MustCompile(`^\d+$`)), valid: []string{"123"}, invalid: []string{"", "12a"}, code: rules.CodePattern},
+		{name: "prefix", rule: rules.HasPrefix("ab"), valid: []string{"ab", "abc"}, invalid: []string{"", "ba"}, code: rules.CodePrefix},
+		{name: "suffix", rule: rules.HasSuffix("yz"), valid: []string{"yz", "xyz"}, invalid: []string{"", "zy"}, code: rules.CodeSuffix},
+		{name: "contains", rule: rules.Contains("@"), valid: []string{"a@b"}, invalid: []string{"ab"}, code: rules.CodeContains},
+		{name: "one of", rule: rules.OneOf("red", "green"), valid: []string{"red", "green"}, invalid: []string{"", "blue", "Red"}, code: rules.CodeOneOf},
+		{name: "alpha", rule: rules.Alpha(), valid: []string{"", "abc", "Ñandú"}, invalid: []string{"ab1", "a b"}, code: rules.CodeAlpha},
+		{name: "alphanumeric", rule: rules.Alphanumeric(), valid: []string{"", "abc123"}, invalid: []string{"abc-123", "a b"}, code: rules.CodeAlphanumeric},
+		{name: "ascii", rule: rules.ASCII(), valid: []string{"", "abc 123 !?"}, invalid: []string{"ñ", "€"}, code: rules.CodeASCII},
+		{name: "printable", rule: rules.Printable(), valid: []string{"", "abc ñ"}, invalid: []string{"a\tb", "a\x00"}, code: rules.CodePrintable},
+		{name: "lowercase", rule: rules.Lowercase(), valid: []string{"", "abc1", "ñ"}, invalid: []string{"Abc", "Ñ"}, code: rules.CodeLowercase},
+		{name: "uppercase", rule: rules.Uppercase(), valid: []string{"", "ABC1", "Ñ"}, invalid: []string{"aBC", "ñ"}, code: rules.CodeUppercase},
+		{name: "trimmed", rule: rules.Trimmed(), valid: []string{"", "a b"}, invalid: []string{" a", "a\n"}, code: rules.CodeTrimmed},
+		{name: "valid utf8", rule: rules.ValidUTF8(), valid: []string{"", "ñ"}, invalid: []string{"\xff", "a\xc3"}, code: rules.CodeUTF8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, s := range tt.valid {
+				assert.NoError(t, tt.rule.Check(s), "value %q", s)
+			}
+
+			for _, s := range tt.invalid {
+				err := tt.rule.Check(s)
+
+				failure, ok := err.(validator.Failure)
+				if assert.True(t, ok, "value %q", s) {
+					assert.Equal(t, tt.code, failure.Code)
+					assert.Equal(t, s, failure.Value)
+					assert.NotEmpty(t, failure.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestStringRules_Params(t *testing.T) {
+	err := rules.MaxLength(50).Check(string(make([]byte, 51)))
+
+	failure := err.(validator.Failure)
+	assert.Equal(t, map[string]any{"max": 50}, failure.Params)
+	assert.Equal(t, "length must be at most 50", failure.Message)
+}
+
+func TestStringRules_WithRuleFor(t *testing.T) {
+	type customer struct {
+		Name string
+	}
+
+	v := validator.New[customer]()
+	validator.RuleFor(v, "name", func(c customer) string { return c.Name }).
+		Check(rules.NotBlank(), rules.Trimmed(), rules.MaxLength(5))
+
+	result := v.Validate(customer{Name: " too long "})
+
+	assert.Equal(t, []string{rules.CodeTrimmed, rules.CodeMaxLength}, result.Codes())
+	assert.Len(t, result.FailuresFor("name"), 2)
+}
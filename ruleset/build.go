@@ -0,0 +1,343 @@
+package ruleset
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/cgxarrie-go/validator/internal/keys"
+	"github.com/cgxarrie-go/validator/tags"
+)
+
+// CodeInvalidType is the failure code reported when a value of a payload
+// cannot be checked with the rules of its field, e.g. a number checked with
+// the email rule
+const CodeInvalidType = "invalid_type"
+
+var payloadType = reflect.TypeOf(map[string]any{})
+
+// Build builds a validator for T from the rules of rs. T must be a struct, a
+// pointer to a struct, or a map[string]any payload, e.g. decoded from JSON.
+//
+// For structs, the field paths and the rules are checked against the fields
+// of T, and problems are reported here as an *Error. For payloads, the rules
+// are checked against the type of every value when validating, and values
+// they cannot be applied to are reported as CodeInvalidType failures. Missing
+// values of a payload are checked as nil.
+//
+// The validators built for structs describe their rules with
+// validator.Describe. The rules of payloads depend on the values, so they are
+// opaque
+func Build[T any](rs *RuleSet) (validator.Validator[T], error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+
+	getters, err := buildGetters(rs, t)
+	if err != nil {
+		return nil, err
+	}
+
+	v := validator.New[T]()
+	if len(getters) == 0 {
+		v.AddStep()
+		return v, nil
+	}
+
+	for i, get := range getters {
+		field, get := rs.Fields[i], get
+		step := v.AddStep(func(src T) error {
+			res := get.validate(reflect.ValueOf(&src).Elem())
+			if res.IsSuccess() {
+				return nil
+			}
+
+			result := validator.Result{}
+			result.AddFailureAt(field.Path, res)
+			return result
+		})
+		if get.description != nil {
+			step.WithDescription(*get.description)
+		}
+	}
+
+	return v, nil
+}
+
+// MustBuild is like Build but panics if rs cannot be applied to T
+func MustBuild[T any](rs *RuleSet) validator.Validator[T] {
+	v, err := Build[T](rs)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// getter validates the field of a value
+type getter struct {
+	validate func(rv reflect.Value) validator.Result
+	// description is nil when the constraints are only known when validating
+	description *validator.Description
+}
+
+func buildGetters(rs *RuleSet, t reflect.Type) ([]getter, error) {
+	getters := make([]getter, 0, len(rs.Fields))
+
+	if t == payloadType {
+		for _, field := range rs.Fields {
+			getters = append(getters, payloadGetter(field))
+		}
+		return getters, nil
+	}
+
+	root := t
+	for root.Kind() == reflect.Pointer {
+		root = root.Elem()
+	}
+	if root.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("ruleset: %s is not a struct or a map[string]any", t)
+	}
+
+	for _, field := range rs.Fields {
+		get, err := structGetter(rs, field, root)
+		if err != nil {
+			return nil, err
+		}
+		getters = append(getters, get)
+	}
+	return getters, nil
+}
+
+// structGetter resolves the path of field in the struct type t and compiles
+// its rules for the type of the field
+func structGetter(rs *RuleSet, field Field, t reflect.Type) (getter, error) {
+	steps := make([][]int, 0)
+	current := t
+
+	for _, name := range strings.Split(field.Path, ".") {
+		for current.Kind() == reflect.Pointer {
+			current = current.Elem()
+		}
+		if current.Kind() != reflect.Struct {
+			return getter{}, rs.fieldError(field, fmt.Sprintf("%s is not a struct", current))
+		}
+
+		index, ok := lookupField(current, name)
+		if !ok {
+			return getter{}, rs.fieldError(field, fmt.Sprintf("%s has no field %q", current, name))
+		}
+		steps = append(steps, index)
+		current = current.FieldByIndex(index).Type
+	}
+
+	rules, err := tags.CompileRules(current, field.specs())
+	if err != nil {
+		var tagErr *tags.Error
+		if errors.As(err, &tagErr) && tagErr.Rule != "" {
+			return getter{}, rs.ruleError(field, tagErr.Rule, tagErr.Msg)
+		}
+		return getter{}, rs.fieldError(field, err.Error())
+	}
+
+	return getter{
+		validate: func(rv reflect.Value) validator.Result {
+			value, ok := fieldValue(rv, steps)
+			if !ok {
+				return rules.Validate(nil)
+			}
+			return rules.Validate(value.Interface())
+		},
+		description: describePath(field.Path, rules),
+	}, nil
+}
+
+// describePath describes rules as the constraints of the field at path,
+// nesting a field for every name of the path
+func describePath(path string, rules *tags.Rules) *validator.Description {
+	names := strings.Split(path, ".")
+	d := validator.Description{Fields: []validator.FieldDescription{{
+		Name:        names[len(names)-1],
+		Required:    rules.Required(),
+		Description: rules.Describe(),
+	}}}
+
+	for i := len(names) - 2; i >= 0; i-- {
+		d = validator.Description{Fields: []validator.FieldDescription{{
+			Name:        names[i],
+			Description: d,
+		}}}
+	}
+	return &d
+}
+
+// lookupField returns the index of the field called name, as the json name of
+// the field or its Go name, including the fields promoted from embedded
+// structs
+func lookupField(t reflect.Type, name string) ([]int, bool) {
+	embedded := make([]reflect.StructField, 0)
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		fieldName := tags.FieldName(sf)
+		if fieldName == name {
+			return []int{i}, true
+		}
+		if fieldName == "" {
+			embedded = append(embedded, sf)
+		}
+	}
+
+	for _, sf := range embedded {
+		et := sf.Type
+		for et.Kind() == reflect.Pointer {
+			et = et.Elem()
+		}
+		if et.Kind() != reflect.Struct {
+			continue
+		}
+		if index, ok := lookupField(et, name); ok {
+			return append(sf.Index, index...), true
+		}
+	}
+
+	return nil, false
+}
+
+// fieldValue returns the field reached by steps from rv, or false when a nil
+// pointer is found on the way
+func fieldValue(rv reflect.Value, steps [][]int) (reflect.Value, bool) {
+	for _, index := range steps {
+		for _, i := range index {
+			for rv.Kind() == reflect.Pointer {
+				if rv.IsNil() {
+					return reflect.Value{}, false
+				}
+				rv = rv.Elem()
+			}
+			rv = rv.Field(i)
+		}
+	}
+	return rv, true
+}
+
+// payloadGetter returns the getter of field in a map[string]any payload
+func payloadGetter(field Field) getter {
+	path := strings.Split(field.Path, ".")
+	rules := newDynamicRules(field.specs())
+
+	return getter{validate: func(rv reflect.Value) validator.Result {
+		var current any = rv.Interface().(map[string]any)
+		for _, name := range path {
+			m, ok := current.(map[string]any)
+			if !ok {
+				return rules.validate(nil)
+			}
+			current = m[name]
+		}
+		return rules.validate(current)
+	}}
+}
+
+// dynamicRules checks the values of a payload, compiling the rules for the
+// type of every value the first time it is seen. The rules after dive are
+// checked on every element in the same way
+type dynamicRules struct {
+	specs    []tags.Spec
+	required bool
+	dive     *dynamicRules
+	compiled sync.Map
+}
+
+type compiledRules struct {
+	rules *tags.Rules
+	err   error
+}
+
+func newDynamicRules(specs []tags.Spec) *dynamicRules {
+	d := &dynamicRules{specs: specs}
+
+	for i, spec := range specs {
+		switch spec.Name {
+		case "required":
+			d.required = true
+		case "dive":
+			d.specs = specs[:i]
+			d.dive = newDynamicRules(specs[i+1:])
+			return d
+		}
+	}
+	return d
+}
+
+func (d *dynamicRules) validate(value any) validator.Result {
+	result := validator.Result{}
+	if value == nil {
+		if d.required {
+			result.AddFailure(tags.Required())
+		}
+		return result
+	}
+
+	t := reflect.TypeOf(value)
+	c, ok := d.compiled.Load(t)
+	if !ok {
+		rules, err := tags.CompileRules(t, d.specs)
+		c, _ = d.compiled.LoadOrStore(t, &compiledRules{rules: rules, err: err})
+	}
+
+	compiled := c.(*compiledRules)
+	if compiled.err != nil {
+		result.AddFailure(invalidType(value, compiled.err))
+		return result
+	}
+	result.Merge(compiled.rules.Validate(value))
+
+	if d.dive != nil {
+		d.validateElements(reflect.ValueOf(value), &result)
+	}
+	return result
+}
+
+func (d *dynamicRules) validateElements(rv reflect.Value, result *validator.Result) {
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if res := d.dive.validate(rv.Index(i).Interface()); res.IsFailure() {
+				result.AddFailureAt(fmt.Sprintf("[%d]", i), res)
+			}
+		}
+	case reflect.Map:
+		mapKeys := rv.MapKeys()
+		keys.Sort(mapKeys)
+
+		for _, key := range mapKeys {
+			if res := d.dive.validate(rv.MapIndex(key).Interface()); res.IsFailure() {
+				result.AddFailureAt(fmt.Sprintf("[%v]", key), res)
+			}
+		}
+	default:
+		result.AddFailure(invalidType(rv.Interface(), fmt.Errorf("rule %q: cannot be applied to %s", "dive", rv.Type())))
+	}
+}
+
+func invalidType(value any, err error) validator.Failure {
+	msg := err.Error()
+	var tagErr *tags.Error
+	if errors.As(err, &tagErr) {
+		msg = tagErr.Msg
+		if tagErr.Rule != "" {
+			msg = fmt.Sprintf("rule %q: %s", tagErr.Rule, tagErr.Msg)
+		}
+	}
+
+	return validator.Failure{
+		Code:    CodeInvalidType,
+		Message: "cannot be checked: " + msg,
+		Value:   value,
+	}
+}
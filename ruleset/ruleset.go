@@ -0,0 +1,278 @@
+// Package ruleset builds validators from rule sets declared in YAML or JSON
+// documents, so that limits can be changed without rebuilding the service.
+//
+// A rule set lists the fields to check with their rules, written as in the
+// validate tags of the tags package, one rule per item:
+//
+//	fields:
+//	  name: [required, max=50]
+//	  email: [omitempty, email]
+//	  age: [gte=18, lte=130]
+//	  address.country: [required, oneof=ES FR PT]
+//	  tags: [max=5, dive, alphanum]
+//
+// Field paths are made of the json names of the fields, or their Go names
+// when they have no json tag, joined by dots. The same document in JSON is
+// also a valid rule set.
+//
+// Rule sets are checked strictly: unknown keys, unknown rules, fields that do
+// not exist in the validated struct and rules that do not apply to the type
+// of their field are reported as an *Error with the line and column where
+// they are found.
+//
+// A Validator holds a rule set that can be replaced with Reload while it is
+// being used, without locking the validations in progress.
+package ruleset
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cgxarrie-go/validator/tags"
+	"gopkg.in/yaml.v3"
+)
+
+// RuleSet is a parsed rule set document
+type RuleSet struct {
+	// Fields are the checked fields, in the document order
+	Fields []Field
+
+	file string
+}
+
+// Field is a field of a rule set with its rules
+type Field struct {
+	// Path is the path of the field, e.g. "address.country"
+	Path  string
+	Rules []Rule
+	// Line and Column locate the field in the document
+	Line   int
+	Column int
+}
+
+// Rule is a rule of a field, with its optional parameter
+type Rule struct {
+	Name  string
+	Param string
+	// Line and Column locate the rule in the document
+	Line   int
+	Column int
+}
+
+// Error reports an invalid rule set
+type Error struct {
+	// File is the name of the rule set file, if read from a file
+	File string
+	// Line and Column locate the problem in the document. Column is zero
+	// when it is not known
+	Line   int
+	Column int
+	// Msg describes the problem
+	Msg string
+}
+
+func (e *Error) Error() string {
+	pos := fmt.Sprintf("line %d", e.Line)
+	if e.Column > 0 {
+		pos += fmt.Sprintf(", column %d", e.Column)
+	}
+	if e.File != "" {
+		pos = e.File + ": " + pos
+	}
+	return "ruleset: " + pos + ": " + e.Msg
+}
+
+// Parse parses a YAML or JSON rule set
+func Parse(data []byte) (*RuleSet, error) {
+	doc := yaml.Node{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, syntaxError(err)
+	}
+
+	if len(doc.Content) == 0 {
+		return nil, &Error{Line: 1, Column: 1, Msg: "empty rule set"}
+	}
+
+	root := resolve(doc.Content[0])
+	if root.Kind != yaml.MappingNode {
+		return nil, errorAt(root, "must be a mapping with a fields key")
+	}
+
+	rs := &RuleSet{Fields: make([]Field, 0)}
+	found := false
+
+	for i := 0; i < len(root.Content); i += 2 {
+		key, value := root.Content[i], resolve(root.Content[i+1])
+		switch key.Value {
+		case "fields":
+			fields, err := parseFields(value)
+			if err != nil {
+				return nil, err
+			}
+			rs.Fields = fields
+			found = true
+		default:
+			return nil, errorAt(key, "unknown key %q", key.Value)
+		}
+	}
+
+	if !found {
+		return nil, errorAt(root, "missing key %q", "fields")
+	}
+	return rs, nil
+}
+
+// ParseFile reads and parses the rule set in file
+func ParseFile(file string) (*RuleSet, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := Parse(data)
+	if err != nil {
+		return nil, withFile(err, file)
+	}
+	rs.file = file
+	return rs, nil
+}
+
+func parseFields(node *yaml.Node) ([]Field, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, errorAt(node, "fields must be a mapping of field paths to rules")
+	}
+
+	fields := make([]Field, 0, len(node.Content)/2)
+	lines := make(map[string]int)
+
+	for i := 0; i < len(node.Content); i += 2 {
+		key, value := node.Content[i], resolve(node.Content[i+1])
+
+		if key.Kind != yaml.ScalarNode || key.Tag != "!!str" {
+			return nil, errorAt(key, "field path must be a string")
+		}
+		if err := checkPath(key.Value); err != nil {
+			return nil, errorAt(key, "%s", err)
+		}
+		if line, ok := lines[key.Value]; ok {
+			return nil, errorAt(key, "field %q is already declared at line %d", key.Value, line)
+		}
+		lines[key.Value] = key.Line
+
+		if value.Kind != yaml.SequenceNode {
+			return nil, errorAt(value, "rules of field %q must be a list", key.Value)
+		}
+
+		field := Field{
+			Path:   key.Value,
+			Rules:  make([]Rule, 0, len(value.Content)),
+			Line:   key.Line,
+			Column: key.Column,
+		}
+		for _, item := range value.Content {
+			rule, err := parseRule(resolve(item))
+			if err != nil {
+				return nil, err
+			}
+			field.Rules = append(field.Rules, rule)
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+func parseRule(node *yaml.Node) (Rule, error) {
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!str" {
+		return Rule{}, errorAt(node, "rule must be a string")
+	}
+
+	name, param, hasParam := strings.Cut(node.Value, "=")
+	name = strings.TrimSpace(name)
+
+	switch {
+	case name == "":
+		return Rule{}, errorAt(node, "empty rule")
+	case hasParam && param == "":
+		return Rule{}, errorAt(node, "rule %q has an empty parameter", name)
+	case !tags.KnownRule(name):
+		return Rule{}, errorAt(node, "unknown rule %q", name)
+	}
+
+	return Rule{Name: name, Param: param, Line: node.Line, Column: node.Column}, nil
+}
+
+func checkPath(path string) error {
+	if path == "" {
+		return fmt.Errorf("empty field path")
+	}
+	for _, segment := range strings.Split(path, ".") {
+		if strings.TrimSpace(segment) == "" {
+			return fmt.Errorf("invalid field path %q", path)
+		}
+	}
+	return nil
+}
+
+// specs returns the rules of the field as tag specs
+func (f Field) specs() []tags.Spec {
+	specs := make([]tags.Spec, len(f.Rules))
+	for i, rule := range f.Rules {
+		specs[i] = tags.Spec{Name: rule.Name, Param: rule.Param}
+	}
+	return specs
+}
+
+// ruleError returns the error reported for the rule of the field named rule
+func (rs *RuleSet) ruleError(f Field, rule string, msg string) error {
+	for _, r := range f.Rules {
+		if r.Name == rule {
+			return &Error{File: rs.file, Line: r.Line, Column: r.Column, Msg: fmt.Sprintf("field %q: rule %q: %s", f.Path, rule, msg)}
+		}
+	}
+	return rs.fieldError(f, msg)
+}
+
+// fieldError returns the error reported for the field
+func (rs *RuleSet) fieldError(f Field, msg string) error {
+	return &Error{File: rs.file, Line: f.Line, Column: f.Column, Msg: fmt.Sprintf("field %q: %s", f.Path, msg)}
+}
+
+// resolve returns the node an alias refers to
+func resolve(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
+
+func errorAt(node *yaml.Node, format string, args ...any) error {
+	return &Error{Line: node.Line, Column: node.Column, Msg: fmt.Sprintf(format, args...)}
+}
+
+var syntaxLine = regexp.MustCompile(`^yaml: line (\d+): `)
+
+// syntaxError converts an error of the YAML parser into an *Error
+func syntaxError(err error) error {
+	msg := err.Error()
+	line := 1
+
+	if m := syntaxLine.FindStringSubmatch(msg); m != nil {
+		line, _ = strconv.Atoi(m[1])
+		msg = msg[len(m[0]):]
+	} else {
+		msg = strings.TrimPrefix(msg, "yaml: ")
+	}
+	return &Error{Line: line, Msg: msg}
+}
+
+func withFile(err error, file string) error {
+	if e, ok := err.(*Error); ok {
+		e.File = file
+	}
+	return err
+}
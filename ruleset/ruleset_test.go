@@ -0,0 +1,318 @@
+package ruleset_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/cgxarrie-go/validator/ruleset"
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	City    string `json:"city"`
+	Country string `json:"country"`
+}
+
+type Audit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type customer struct {
+	Audit
+	Name    string   `json:"name"`
+	Email   string   `json:"email"`
+	Age     int      `json:"age"`
+	Tags    []string `json:"tags"`
+	Address *address `json:"address"`
+	Notes   string
+}
+
+const customerRules = `
+fields:
+  name: [required, max=10]
+  email: [omitempty, email]
+  age: [gte=18, lte=130]
+  tags: [max=2, dive, alphanum]
+  address.country: [required, oneof=ES FR]
+  created_by: [required]
+`
+
+func parse(t *testing.T, doc string) *ruleset.RuleSet {
+	t.Helper()
+	rs, err := ruleset.Parse([]byte(doc))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return rs
+}
+
+func paths(result validator.Result) []string {
+	paths := make([]string, 0)
+	for _, f := range result.Failures() {
+		paths = append(paths, f.Path)
+	}
+	return paths
+}
+
+func TestParse_WhenDocumentIsValid_ShouldReturnFieldsInOrder(t *testing.T) {
+	rs, err := ruleset.Parse([]byte(customerRules))
+
+	assert.NoError(t, err)
+	assert.Len(t, rs.Fields, 6)
+	assert.Equal(t, "name", rs.Fields[0].Path)
+	assert.Equal(t, []ruleset.Rule{
+		{Name: "required", Line: 3, Column: 10},
+		{Name: "max", Param: "10", Line: 3, Column: 20},
+	}, rs.Fields[0].Rules)
+	assert.Equal(t, "address.country", rs.Fields[4].Path)
+	assert.Equal(t, 7, rs.Fields[4].Line)
+	assert.Equal(t, 3, rs.Fields[4].Column)
+}
+
+func TestParse_WhenDocumentIsJSON_ShouldParseIt(t *testing.T) {
+	doc := `{"fields": {"name": ["required", "max=10"], "tags": ["dive", "pattern=^[a-z,]+$"]}}`
+
+	rs, err := ruleset.Parse([]byte(doc))
+
+	assert.NoError(t, err)
+	assert.Len(t, rs.Fields, 2)
+	assert.Equal(t, ruleset.Rule{Name: "pattern", Param: "^[a-z,]+$", Line: 1, Column: 62}, rs.Fields[1].Rules[1])
+}
+
+func TestParse_WhenDocumentIsInvalid_ShouldReturnErrorWithPosition(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		err  string
+	}{
+		{name: "empty", doc: "", err: "ruleset: line 1, column 1: empty rule set"},
+		{name: "not a mapping", doc: "- name", err: "ruleset: line 1, column 1: must be a mapping with a fields key"},
+		{name: "missing fields", doc: "other: 1", err: `ruleset: line 1, column 1: unknown key "other"`},
+		{name: "no fields key", doc: "{}", err: `ruleset: line 1, column 1: missing key "fields"`},
+		{name: "fields not a mapping", doc: "fields: [name]", err: "ruleset: line 1, column 9: fields must be a mapping of field paths to rules"},
+		{name: "rules not a list", doc: "fields:\n  name: required", err: `ruleset: line 2, column 9: rules of field "name" must be a list`},
+		{name: "rule not a string", doc: "fields:\n  name: [required, 5]", err: "ruleset: line 2, column 20: rule must be a string"},
+		{name: "unknown rule", doc: "fields:\n  name:\n    - required\n    - maxx=5", err: `ruleset: line 4, column 7: unknown rule "maxx"`},
+		{name: "empty parameter", doc: "fields:\n  name: [max=]", err: `ruleset: line 2, column 10: rule "max" has an empty parameter`},
+		{name: "empty path", doc: "fields:\n  '': [required]", err: "ruleset: line 2, column 3: empty field path"},
+		{name: "invalid path", doc: "fields:\n  address..city: [required]", err: `ruleset: line 2, column 3: invalid field path "address..city"`},
+		{name: "duplicated field", doc: "fields:\n  name: [required]\n  name: [max=3]", err: `ruleset: line 3, column 3: field "name" is already declared at line 2`},
+		{name: "syntax", doc: "fields:\n  name: [required]\n\tage: [min=1]", err: "ruleset: line 3: found character that cannot start any token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs, err := ruleset.Parse([]byte(tt.doc))
+
+			assert.Nil(t, rs)
+			assert.EqualError(t, err, tt.err)
+			assert.IsType(t, &ruleset.Error{}, err)
+		})
+	}
+}
+
+func TestParseFile_WhenFileIsInvalid_ShouldReportFileName(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "customer.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte("fields:\n  name: [nope]"), 0o644))
+
+	_, err := ruleset.ParseFile(file)
+
+	assert.EqualError(t, err, "ruleset: "+file+`: line 2, column 10: unknown rule "nope"`)
+}
+
+func TestBuild_WhenRulesDoNotFitStruct_ShouldReturnErrorWithPosition(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		err  string
+	}{
+		{name: "unknown field", doc: "fields:\n  phone: [required]", err: `ruleset: line 2, column 3: field "phone": ruleset_test.customer has no field "phone"`},
+		{name: "unknown nested field", doc: "fields:\n  address.zip: [required]", err: `ruleset: line 2, column 3: field "address.zip": ruleset_test.address has no field "zip"`},
+		{name: "not a struct", doc: "fields:\n  name.first: [required]", err: `ruleset: line 2, column 3: field "name.first": string is not a struct`},
+		{name: "rule not applicable", doc: "fields:\n  age: [gte=18, email]", err: `ruleset: line 2, column 17: field "age": rule "email": cannot be applied to int`},
+		{name: "invalid parameter", doc: "fields:\n  age: [gte=old]", err: `ruleset: line 2, column 9: field "age": rule "gte": invalid parameter "old"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := ruleset.Build[customer](parse(t, tt.doc))
+
+			assert.Nil(t, v)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestBuild_WhenTypeIsNotSupported_ShouldReturnError(t *testing.T) {
+	_, err := ruleset.Build[[]string](parse(t, customerRules))
+
+	assert.EqualError(t, err, "ruleset: []string is not a struct or a map[string]any")
+}
+
+func TestBuild_WhenStructIsValid_ShouldReturnSuccess(t *testing.T) {
+	v := ruleset.MustBuild[customer](parse(t, customerRules))
+
+	result := v.Validate(customer{
+		Audit:   Audit{CreatedBy: "admin"},
+		Name:    "John",
+		Age:     30,
+		Tags:    []string{"vip"},
+		Address: &address{Country: "ES"},
+	})
+
+	assert.True(t, result.IsSuccess(), result.Error())
+}
+
+func TestBuild_WhenStructIsInvalid_ShouldReportFailuresWithPaths(t *testing.T) {
+	v := ruleset.MustBuild[*customer](parse(t, customerRules))
+
+	result := v.Validate(&customer{
+		Name:    "John Smith Jr",
+		Email:   "john",
+		Age:     12,
+		Tags:    []string{"a-b", "ok", "c d"},
+		Address: &address{Country: "IT"},
+	})
+
+	assert.Equal(t, []string{"name", "email", "age", "tags", "tags[0]", "tags[2]", "address.country", "created_by"}, paths(result))
+	assert.Equal(t, []string{"max_length", "email", "min", "max_count", "alphanumeric", "one_of", "required"}, result.Codes())
+}
+
+func TestBuild_WhenNestedPointerIsNil_ShouldCheckFieldAsMissing(t *testing.T) {
+	v := ruleset.MustBuild[customer](parse(t, "fields:\n  address.country: [required]\n  address.city: [max=3]"))
+
+	result := v.Validate(customer{})
+
+	assert.Equal(t, []string{"address.country"}, paths(result))
+	assert.Equal(t, validator.CodeRequired, result.Failures()[0].Code)
+}
+
+func TestBuild_WhenRuleSetHasNoFields_ShouldReturnSuccess(t *testing.T) {
+	v := ruleset.MustBuild[customer](parse(t, "fields: {}"))
+
+	result := v.Validate(customer{})
+
+	assert.True(t, result.IsSuccess())
+}
+
+func TestBuild_WhenPayloadIsValidated_ShouldCheckDynamicValues(t *testing.T) {
+	doc := `
+fields:
+  name: [required, max=10]
+  age: [gte=18]
+  tags: [max=2, dive, alphanum]
+  address.country: [required, oneof=ES FR]
+  scores: [dive, lte=10]
+`
+	v := ruleset.MustBuild[map[string]any](parse(t, doc))
+	payload := map[string]any{}
+	err := json.Unmarshal([]byte(`{
+		"name": "John Smith Jr",
+		"age": 12,
+		"tags": ["a-b", 5, "ok"],
+		"address": {"country": "IT"},
+		"scores": {"b": 11, "a": 3}
+	}`), &payload)
+	assert.NoError(t, err)
+
+	result := v.Validate(payload)
+
+	assert.Equal(t, []string{"name", "age", "tags", "tags[0]", "tags[1]", "address.country", "scores[b]"}, paths(result))
+	assert.Equal(t, ruleset.CodeInvalidType, result.Failures()[4].Code)
+	assert.Equal(t, `cannot be checked: rule "alphanum": cannot be applied to float64`, result.Failures()[4].Message)
+	assert.Equal(t, float64(5), result.Failures()[4].Value)
+}
+
+func TestBuild_WhenPayloadArraysHoldObjectsOrArrays_ShouldCheckUniqueDeeply(t *testing.T) {
+	v := ruleset.MustBuild[map[string]any](parse(t, "fields:\n  tags: [unique]\n  lines: [unique]"))
+	payload := map[string]any{}
+	err := json.Unmarshal([]byte(`{
+		"tags": [[1], [2], [1], [1, 2]],
+		"lines": [{"sku": "a", "qty": 1}, {"sku": "a", "qty": 2}, {"qty": 1, "sku": "a"}]
+	}`), &payload)
+	assert.NoError(t, err)
+
+	result := v.Validate(payload)
+
+	assert.Equal(t, []string{"tags[2]", "lines[2]"}, paths(result))
+	assert.Equal(t, map[string]any{"index": 0}, result.Failures()[0].Params)
+	assert.Equal(t, map[string]any{"index": 0}, result.Failures()[1].Params)
+}
+
+func TestBuild_WhenPayloadValuesAreMissing_ShouldCheckThemAsNil(t *testing.T) {
+	v := ruleset.MustBuild[map[string]any](parse(t, "fields:\n  name: [required]\n  address.country: [required]\n  email: [email]"))
+
+	result := v.Validate(map[string]any{"address": "not an object"})
+
+	assert.Equal(t, []string{"name", "address.country"}, paths(result))
+}
+
+func TestValidator_WhenReloaded_ShouldUseNewRules(t *testing.T) {
+	v, err := ruleset.New[customer](parse(t, "fields:\n  name: [max=10]"))
+	assert.NoError(t, err)
+	c := customer{Name: "John Smith Jr"}
+	assert.False(t, v.Validate(c).IsSuccess())
+
+	err = v.Reload(parse(t, "fields:\n  name: [max=20]"))
+
+	assert.NoError(t, err)
+	assert.True(t, v.Validate(c).IsSuccess())
+}
+
+func TestValidator_WhenReloadFails_ShouldKeepCurrentRules(t *testing.T) {
+	v, err := ruleset.New[customer](parse(t, "fields:\n  name: [max=10]"))
+	assert.NoError(t, err)
+
+	err = v.Reload(parse(t, "fields:\n  phone: [max=20]"))
+
+	assert.Error(t, err)
+	assert.False(t, v.Validate(customer{Name: "John Smith Jr"}).IsSuccess())
+}
+
+func TestValidator_WhenReloadingFile_ShouldReadIt(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "customer.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte("fields:\n  age: [gte=18]"), 0o644))
+	v, err := ruleset.NewFromFile[customer](file)
+	assert.NoError(t, err)
+	assert.False(t, v.Validate(customer{Age: 16}).IsSuccess())
+
+	assert.NoError(t, os.WriteFile(file, []byte("fields:\n  age: [gte=16]"), 0o644))
+	err = v.ReloadFile(file)
+
+	assert.NoError(t, err)
+	assert.True(t, v.Validate(customer{Age: 16}).IsSuccess())
+}
+
+func TestValidator_WhenReloadedConcurrently_ShouldValidateWithWholeRuleSets(t *testing.T) {
+	strict := parse(t, "fields:\n  name: [max=3]\n  age: [gte=18]")
+	lax := parse(t, "fields:\n  name: [max=30]\n  age: [gte=0]")
+	v, err := ruleset.New[customer](strict)
+	assert.NoError(t, err)
+	c := customer{Name: "John", Age: 16}
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			if i%2 == 0 {
+				_ = v.Reload(lax)
+			} else {
+				_ = v.Reload(strict)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			// both fields fail with the strict rules and none with the lax ones
+			n := len(v.Validate(c).Failures())
+			assert.True(t, n == 0 || n == 2, "got %d failures", n)
+		}
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,77 @@
+package ruleset
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/cgxarrie-go/validator"
+)
+
+// Validator is a validator for T built from a rule set, whose rules can be
+// replaced with Reload while it is being used. Every validation uses the
+// rules in place when it starts
+type Validator[T any] struct {
+	current atomic.Value
+}
+
+// holder keeps the type stored in the atomic value constant
+type holder[T any] struct {
+	validator validator.Validator[T]
+}
+
+// New returns a Validator for T with the rules of rs
+func New[T any](rs *RuleSet) (*Validator[T], error) {
+	v := &Validator[T]{}
+	if err := v.Reload(rs); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// NewFromFile returns a Validator for T with the rules in file
+func NewFromFile[T any](file string) (*Validator[T], error) {
+	rs, err := ParseFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return New[T](rs)
+}
+
+// Reload replaces the rules of the validator by the rules of rs. When rs
+// cannot be applied to T, the error is returned and the current rules are
+// kept
+func (v *Validator[T]) Reload(rs *RuleSet) error {
+	built, err := Build[T](rs)
+	if err != nil {
+		return err
+	}
+
+	v.current.Store(holder[T]{validator: built})
+	return nil
+}
+
+// ReloadFile replaces the rules of the validator by the rules in file. When
+// the file is not a valid rule set, the error is returned and the current
+// rules are kept
+func (v *Validator[T]) ReloadFile(file string) error {
+	rs, err := ParseFile(file)
+	if err != nil {
+		return err
+	}
+	return v.Reload(rs)
+}
+
+// Validate validates src with the current rules
+func (v *Validator[T]) Validate(src T) validator.Result {
+	return v.ValidateContext(context.Background(), src)
+}
+
+// ValidateContext validates src with the current rules, passing ctx to the
+// validation
+func (v *Validator[T]) ValidateContext(ctx context.Context, src T) validator.Result {
+	current := v.current.Load().(holder[T]).validator
+	if cv, ok := current.(validator.ContextValidator[T]); ok {
+		return cv.ValidateContext(ctx, src)
+	}
+	return current.Validate(src)
+}
@@ -0,0 +1,488 @@
+package schema
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// node is a compiled schema
+type node struct {
+	// ptr is the JSON Pointer of the subschema in the document
+	ptr string
+	// allow is set for the boolean schemas, true to accept any value and
+	// false to reject them all
+	allow *bool
+
+	types []string
+	enum  []any
+	// constant is the value of const, when hasConst is true
+	constant any
+	hasConst bool
+
+	minLength *int
+	maxLength *int
+	pattern   *regexp.Regexp
+
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+	multipleOf       *float64
+
+	properties        map[string]*node
+	patternProperties []patternProperty
+	additional        *node
+	required          []string
+	minProperties     *int
+	maxProperties     *int
+
+	prefixItems []*node
+	items       *node
+	minItems    *int
+	maxItems    *int
+	uniqueItems bool
+
+	allOf []*node
+	anyOf []*node
+	oneOf []*node
+	not   *node
+	ref   *node
+}
+
+type patternProperty struct {
+	pattern *regexp.Regexp
+	schema  *node
+}
+
+// compiler compiles the subschemas of a document, reusing the nodes of the
+// subschemas already compiled, so references can be recursive
+type compiler struct {
+	root  any
+	nodes map[string]*node
+}
+
+func compile(root any) (*node, error) {
+	c := &compiler{root: root, nodes: make(map[string]*node)}
+	n, err := c.compile(root, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := mustNotLoop(n); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// compile compiles raw, the subschema found at ptr
+func (c *compiler) compile(raw any, ptr string) (*node, error) {
+	if n, ok := c.nodes[ptr]; ok {
+		return n, nil
+	}
+
+	n := &node{ptr: ptr}
+	c.nodes[ptr] = n
+
+	if allow, ok := raw.(bool); ok {
+		n.allow = &allow
+		return n, nil
+	}
+
+	obj, ok := raw.(map[string]any)
+	if !ok {
+		return nil, &Error{Pointer: ptr, Msg: "must be an object or a boolean"}
+	}
+
+	keywords := make([]string, 0, len(obj))
+	for keyword := range obj {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+
+	for _, keyword := range keywords {
+		if err := c.keyword(n, keyword, obj[keyword], pointer(ptr, keyword)); err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+// keyword compiles the keyword of n, whose value is raw and found at ptr.
+// Unknown keywords are annotations, and are ignored
+func (c *compiler) keyword(n *node, keyword string, raw any, ptr string) error {
+	var err error
+
+	switch keyword {
+	case "type":
+		n.types, err = typesOf(raw, ptr)
+	case "enum":
+		values, ok := raw.([]any)
+		if !ok {
+			return &Error{Pointer: ptr, Msg: "must be an array"}
+		}
+		n.enum = values
+	case "const":
+		n.constant, n.hasConst = raw, true
+
+	case "minLength":
+		n.minLength, err = count(raw, ptr)
+	case "maxLength":
+		n.maxLength, err = count(raw, ptr)
+	case "pattern":
+		n.pattern, err = pattern(raw, ptr)
+
+	case "minimum":
+		n.minimum, err = limit(raw, ptr)
+	case "maximum":
+		n.maximum, err = limit(raw, ptr)
+	case "exclusiveMinimum":
+		n.exclusiveMinimum, err = limit(raw, ptr)
+	case "exclusiveMaximum":
+		n.exclusiveMaximum, err = limit(raw, ptr)
+	case "multipleOf":
+		n.multipleOf, err = limit(raw, ptr)
+		if err == nil && *n.multipleOf <= 0 {
+			err = &Error{Pointer: ptr, Msg: "must be greater than 0"}
+		}
+
+	case "properties", "patternProperties", "$defs", "definitions":
+		props, ok := raw.(map[string]any)
+		if !ok {
+			return &Error{Pointer: ptr, Msg: "must be an object"}
+		}
+		return c.properties(n, keyword, props, ptr)
+	case "additionalProperties":
+		n.additional, err = c.compile(raw, ptr)
+	case "required":
+		n.required, err = names(raw, ptr)
+	case "minProperties":
+		n.minProperties, err = count(raw, ptr)
+	case "maxProperties":
+		n.maxProperties, err = count(raw, ptr)
+
+	case "items":
+		// before draft 2020-12, an array of items are the prefix items
+		if _, ok := raw.([]any); ok {
+			n.prefixItems, err = c.list(raw, ptr)
+			break
+		}
+		n.items, err = c.compile(raw, ptr)
+	case "prefixItems":
+		n.prefixItems, err = c.list(raw, ptr)
+	case "minItems":
+		n.minItems, err = count(raw, ptr)
+	case "maxItems":
+		n.maxItems, err = count(raw, ptr)
+	case "uniqueItems":
+		unique, ok := raw.(bool)
+		if !ok {
+			return &Error{Pointer: ptr, Msg: "must be a boolean"}
+		}
+		n.uniqueItems = unique
+
+	case "allOf":
+		n.allOf, err = c.list(raw, ptr)
+	case "anyOf":
+		n.anyOf, err = c.list(raw, ptr)
+	case "oneOf":
+		n.oneOf, err = c.list(raw, ptr)
+	case "not":
+		n.not, err = c.compile(raw, ptr)
+	case "$ref":
+		n.ref, err = c.reference(raw, ptr)
+	}
+
+	return err
+}
+
+// properties compiles the subschemas of an object keyword. The subschemas in
+// $defs and definitions are only compiled to report their errors early
+func (c *compiler) properties(n *node, keyword string, props map[string]any, ptr string) error {
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		schema, err := c.compile(props[name], pointer(ptr, name))
+		if err != nil {
+			return err
+		}
+
+		switch keyword {
+		case "properties":
+			if n.properties == nil {
+				n.properties = make(map[string]*node)
+			}
+			n.properties[name] = schema
+		case "patternProperties":
+			re, err := regexp.Compile(name)
+			if err != nil {
+				return &Error{Pointer: pointer(ptr, name), Msg: fmt.Sprintf("invalid pattern: %s", err)}
+			}
+			n.patternProperties = append(n.patternProperties, patternProperty{pattern: re, schema: schema})
+		}
+	}
+	return nil
+}
+
+// list compiles an array of subschemas
+func (c *compiler) list(raw any, ptr string) ([]*node, error) {
+	items, ok := raw.([]any)
+	if !ok || len(items) == 0 {
+		return nil, &Error{Pointer: ptr, Msg: "must be a non-empty array"}
+	}
+
+	nodes := make([]*node, len(items))
+	for i, item := range items {
+		n, err := c.compile(item, index(ptr, i))
+		if err != nil {
+			return nil, err
+		}
+		nodes[i] = n
+	}
+	return nodes, nil
+}
+
+// reference resolves a reference to a part of the document, written as a
+// JSON Pointer fragment, e.g. "#/$defs/address"
+func (c *compiler) reference(raw any, ptr string) (*node, error) {
+	ref, ok := raw.(string)
+	if !ok {
+		return nil, &Error{Pointer: ptr, Msg: "must be a string"}
+	}
+	if !strings.HasPrefix(ref, "#") {
+		return nil, &Error{Pointer: ptr, Msg: fmt.Sprintf("reference %q is not local to the document", ref)}
+	}
+
+	fragment, err := url.PathUnescape(ref[1:])
+	if err != nil || (fragment != "" && !strings.HasPrefix(fragment, "/")) {
+		return nil, &Error{Pointer: ptr, Msg: fmt.Sprintf("reference %q is not a JSON Pointer", ref)}
+	}
+
+	target, ok := resolve(c.root, fragment)
+	if !ok {
+		return nil, &Error{Pointer: ptr, Msg: fmt.Sprintf("reference %q not found", ref)}
+	}
+	return c.compile(target, canonical(fragment))
+}
+
+// mustNotLoop returns an error when the references of the schema loop
+// without descending into the value, e.g. when a subschema refers to
+// itself, which would validate the value forever. References reached
+// through the properties or the items of a value are recursive schemas,
+// and are allowed
+func mustNotLoop(root *node) error {
+	nodes := []*node{root}
+	seen := map[*node]bool{root: true}
+	for i := 0; i < len(nodes); i++ {
+		for _, next := range append(nodes[i].inPlace(), nodes[i].nested()...) {
+			if !seen[next] {
+				seen[next] = true
+				nodes = append(nodes, next)
+			}
+		}
+	}
+
+	done := make(map[*node]bool, len(nodes))
+	path := make([]*node, 0)
+
+	var visit func(n *node) error
+	visit = func(n *node) error {
+		for i, p := range path {
+			if p == n {
+				ptrs := make([]string, 0, len(path)-i+1)
+				for _, p := range append(path[i:], n) {
+					ptrs = append(ptrs, "#"+p.ptr)
+				}
+				return &Error{Pointer: n.ptr, Msg: "circular $ref: " + strings.Join(ptrs, " -> ")}
+			}
+		}
+		if done[n] {
+			return nil
+		}
+
+		path = append(path, n)
+		for _, next := range n.inPlace() {
+			if err := visit(next); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		done[n] = true
+		return nil
+	}
+
+	for _, n := range nodes {
+		if err := visit(n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inPlace returns the subschemas applied to the same value as n
+func (n *node) inPlace() []*node {
+	nodes := make([]*node, 0, len(n.allOf)+len(n.anyOf)+len(n.oneOf)+2)
+	if n.ref != nil {
+		nodes = append(nodes, n.ref)
+	}
+	nodes = append(nodes, n.allOf...)
+	nodes = append(nodes, n.anyOf...)
+	nodes = append(nodes, n.oneOf...)
+	if n.not != nil {
+		nodes = append(nodes, n.not)
+	}
+	return nodes
+}
+
+// nested returns the subschemas applied to the properties and the items of
+// the value of n
+func (n *node) nested() []*node {
+	nodes := make([]*node, 0, len(n.properties)+len(n.patternProperties)+len(n.prefixItems)+2)
+	names := make([]string, 0, len(n.properties))
+	for name := range n.properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		nodes = append(nodes, n.properties[name])
+	}
+	for _, p := range n.patternProperties {
+		nodes = append(nodes, p.schema)
+	}
+	if n.additional != nil {
+		nodes = append(nodes, n.additional)
+	}
+	nodes = append(nodes, n.prefixItems...)
+	if n.items != nil {
+		nodes = append(nodes, n.items)
+	}
+	return nodes
+}
+
+// resolve returns the value found at the JSON Pointer ptr of doc
+func resolve(doc any, ptr string) (any, bool) {
+	if ptr == "" {
+		return doc, true
+	}
+
+	current := doc
+	for _, token := range strings.Split(ptr[1:], "/") {
+		token = strings.ReplaceAll(token, "~1", "/")
+		token = strings.ReplaceAll(token, "~0", "~")
+
+		switch v := current.(type) {
+		case map[string]any:
+			next, ok := v[token]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			current = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// canonical returns ptr with its tokens escaped as the compiler does, so a
+// reference reuses the node of the subschema it points to
+func canonical(ptr string) string {
+	if ptr == "" {
+		return ""
+	}
+
+	result := ""
+	for _, token := range strings.Split(ptr[1:], "/") {
+		token = strings.ReplaceAll(token, "~1", "/")
+		token = strings.ReplaceAll(token, "~0", "~")
+		result = pointer(result, token)
+	}
+	return result
+}
+
+func typesOf(raw any, ptr string) ([]string, error) {
+	valid := func(t string) bool {
+		switch t {
+		case typeNull, typeBoolean, typeObject, typeArray, typeNumber, typeString, typeInteger:
+			return true
+		}
+		return false
+	}
+
+	if t, ok := raw.(string); ok {
+		if !valid(t) {
+			return nil, &Error{Pointer: ptr, Msg: fmt.Sprintf("unknown type %q", t)}
+		}
+		return []string{t}, nil
+	}
+
+	types, err := names(raw, ptr)
+	if err != nil {
+		return nil, &Error{Pointer: ptr, Msg: "must be a string or an array of strings"}
+	}
+	for _, t := range types {
+		if !valid(t) {
+			return nil, &Error{Pointer: ptr, Msg: fmt.Sprintf("unknown type %q", t)}
+		}
+	}
+	return types, nil
+}
+
+func names(raw any, ptr string) ([]string, error) {
+	items, ok := raw.([]any)
+	if !ok {
+		return nil, &Error{Pointer: ptr, Msg: "must be an array of strings"}
+	}
+
+	values := make([]string, len(items))
+	for i, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, &Error{Pointer: ptr, Msg: "must be an array of strings"}
+		}
+		values[i] = s
+	}
+	return values, nil
+}
+
+func count(raw any, ptr string) (*int, error) {
+	n, ok := raw.(float64)
+	if !ok || n < 0 || n != math.Trunc(n) {
+		return nil, &Error{Pointer: ptr, Msg: "must be a non-negative integer"}
+	}
+	i := int(n)
+	return &i, nil
+}
+
+func limit(raw any, ptr string) (*float64, error) {
+	n, ok := raw.(float64)
+	if !ok {
+		return nil, &Error{Pointer: ptr, Msg: "must be a number"}
+	}
+	return &n, nil
+}
+
+func pattern(raw any, ptr string) (*regexp.Regexp, error) {
+	s, ok := raw.(string)
+	if !ok {
+		return nil, &Error{Pointer: ptr, Msg: "must be a string"}
+	}
+	re, err := regexp.Compile(s)
+	if err != nil {
+		return nil, &Error{Pointer: ptr, Msg: fmt.Sprintf("invalid pattern: %s", err)}
+	}
+	return re, nil
+}
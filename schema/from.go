@@ -0,0 +1,127 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/cgxarrie-go/validator"
+)
+
+// Failure codes reported by the validators built from JSON Schemas, for the
+// keywords with no equivalent rule. The other keywords report the codes of
+// their rules, e.g. rules.CodeMaxLength for maxLength or rules.CodeOneOf for
+// enum
+const (
+	// CodeType is reported when the value is not of the type of the schema
+	CodeType = "type"
+	// CodeAdditionalProperty is reported for every property not allowed by
+	// additionalProperties
+	CodeAdditionalProperty = "additional_property"
+	// CodeAnyOf is reported when the value matches none of the anyOf schemas
+	CodeAnyOf = "any_of"
+	// CodeExactlyOneOf is reported when the value does not match exactly one
+	// of the oneOf schemas
+	CodeExactlyOneOf = "exactly_one_of"
+	// CodeNot is reported when the value matches the not schema
+	CodeNot = "not"
+	// CodeNotAllowed is reported for the values checked by a false schema
+	CodeNotAllowed = "not_allowed"
+)
+
+// Message keys of the failures of the object keywords, which are reported
+// with the codes of the collection rules
+const (
+	// KeyMinProperties is the message key of minProperties failures
+	KeyMinProperties = "min_properties"
+	// KeyMaxProperties is the message key of maxProperties failures
+	KeyMaxProperties = "max_properties"
+)
+
+// Error reports a JSON Schema document that cannot be used to validate
+type Error struct {
+	// Pointer is the JSON Pointer of the keyword that is not valid
+	Pointer string
+	// Msg describes the problem
+	Msg string
+}
+
+func (e *Error) Error() string {
+	if e.Pointer == "" {
+		return "schema: " + e.Msg
+	}
+	return fmt.Sprintf("schema: %s: %s", e.Pointer, e.Msg)
+}
+
+// FromJSONSchema builds a validator for untyped values, such as the payloads
+// decoded from JSON into an any or a map[string]any, from a JSON Schema
+// document.
+//
+// The type, properties, patternProperties, additionalProperties, required,
+// enum, const, pattern, minLength, maxLength, minimum, maximum,
+// exclusiveMinimum, exclusiveMaximum, multipleOf, items, prefixItems,
+// minItems, maxItems, uniqueItems, minProperties, maxProperties, allOf, anyOf,
+// oneOf and not keywords are checked, and $ref can point to any part of the
+// document, e.g. "#/$defs/address". Other keywords, such as format, are
+// annotations and are not checked.
+//
+// Failures are reported with the JSON Pointer of the failing value as path,
+// e.g. "/lines/3/name". Documents that cannot be used, such as documents with
+// invalid keywords, references to other documents or references looping
+// back to themselves without descending into the value, are reported here as
+// an *Error
+func FromJSONSchema[T any](doc []byte) (validator.Validator[T], error) {
+	var raw any
+	if err := json.Unmarshal(doc, &raw); err != nil {
+		return nil, &Error{Msg: err.Error()}
+	}
+
+	root, err := compile(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	v := validator.New[T]()
+	v.AddStep(func(src T) error {
+		result := validator.Result{}
+		root.validate(value(src), "", &result)
+		if result.IsSuccess() {
+			return nil
+		}
+		return result
+	})
+	return v, nil
+}
+
+// FromJSONSchemaFile builds a validator for untyped values from the JSON
+// Schema document in file
+func FromJSONSchemaFile[T any](file string) (validator.Validator[T], error) {
+	doc, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return FromJSONSchema[T](doc)
+}
+
+// MustFromJSONSchema is like FromJSONSchema but panics if doc cannot be used
+func MustFromJSONSchema[T any](doc []byte) validator.Validator[T] {
+	v, err := FromJSONSchema[T](doc)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// value returns src as an untyped value, so typed nil maps and slices are
+// checked as null
+func value(src any) any {
+	rv := reflect.ValueOf(src)
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+	}
+	return src
+}
@@ -0,0 +1,292 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/cgxarrie-go/validator/rules"
+	"github.com/cgxarrie-go/validator/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+const orderSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["id", "customer", "lines"],
+  "additionalProperties": false,
+  "properties": {
+    "id": {"type": "string", "pattern": "^ORD-[0-9]+$"},
+    "status": {"enum": ["new", "paid", "shipped"]},
+    "version": {"const": 2},
+    "customer": {"$ref": "#/$defs/customer"},
+    "lines": {
+      "type": "array",
+      "minItems": 1,
+      "maxItems": 3,
+      "uniqueItems": true,
+      "items": {"$ref": "#/$defs/line"}
+    },
+    "notes": {"type": ["string", "null"], "maxLength": 5}
+  },
+  "$defs": {
+    "customer": {
+      "type": "object",
+      "required": ["name"],
+      "properties": {
+        "name": {"type": "string", "minLength": 2},
+        "email": {"type": "string", "format": "email"}
+      }
+    },
+    "line": {
+      "type": "object",
+      "properties": {
+        "sku": {"type": "string"},
+        "quantity": {"type": "integer", "minimum": 1, "maximum": 10},
+        "price": {"type": "number", "exclusiveMinimum": 0, "multipleOf": 0.01}
+      }
+    }
+  }
+}`
+
+func decode(t *testing.T, doc string) map[string]any {
+	t.Helper()
+	payload := map[string]any{}
+	if !assert.NoError(t, json.Unmarshal([]byte(doc), &payload)) {
+		t.FailNow()
+	}
+	return payload
+}
+
+func codesByPath(result validator.Result) map[string][]string {
+	codes := make(map[string][]string)
+	for _, f := range result.Failures() {
+		codes[f.Path] = append(codes[f.Path], f.Code)
+	}
+	return codes
+}
+
+func TestFromJSONSchema_WhenPayloadIsValid_ShouldReturnSuccess(t *testing.T) {
+	// Arrange
+	v := schema.MustFromJSONSchema[map[string]any]([]byte(orderSchema))
+	payload := decode(t, `{
+		"id": "ORD-1",
+		"status": "paid",
+		"version": 2,
+		"customer": {"name": "Ann", "email": "not checked"},
+		"lines": [{"sku": "A", "quantity": 2, "price": 9.99}],
+		"notes": null
+	}`)
+
+	// Act
+	result := v.Validate(payload)
+
+	// Assert
+	assert.True(t, result.IsSuccess(), result.Error())
+}
+
+func TestFromJSONSchema_WhenPayloadIsInvalid_ShouldReportFailuresWithJSONPointers(t *testing.T) {
+	// Arrange
+	v := schema.MustFromJSONSchema[any]([]byte(orderSchema))
+	payload := decode(t, `{
+		"id": "123",
+		"status": "lost",
+		"version": 1,
+		"customer": {"name": "A"},
+		"lines": [
+			{"sku": "A", "quantity": 1.5, "price": 0},
+			{"sku": "B", "quantity": 11, "price": 1.001},
+			{"sku": "B", "quantity": 11, "price": 1.001}
+		],
+		"notes": "too long",
+		"extra/field": true
+	}`)
+
+	// Act
+	result := v.Validate(payload)
+
+	// Assert
+	assert.Equal(t, map[string][]string{
+		"/id":               {validator.CodePattern},
+		"/status":           {rules.CodeOneOf},
+		"/version":          {rules.CodeEqual},
+		"/customer/name":    {validator.CodeMinLength},
+		"/lines/0/quantity": {schema.CodeType},
+		"/lines/0/price":    {rules.CodeGreaterThan},
+		"/lines/1/quantity": {rules.CodeMax},
+		"/lines/1/price":    {rules.CodeMultipleOf},
+		"/lines/2":          {rules.CodeUnique},
+		"/lines/2/quantity": {rules.CodeMax},
+		"/lines/2/price":    {rules.CodeMultipleOf},
+		"/notes":            {validator.CodeMaxLength},
+		"/extra~1field":     {schema.CodeAdditionalProperty},
+	}, codesByPath(result))
+}
+
+func TestFromJSONSchema_WhenRequiredPropertiesAreMissing_ShouldReportTheirPaths(t *testing.T) {
+	// Arrange
+	v := schema.MustFromJSONSchema[map[string]any]([]byte(orderSchema))
+
+	// Act
+	result := v.Validate(decode(t, `{"customer": {}}`))
+
+	// Assert
+	assert.Equal(t, map[string][]string{
+		"/id":            {validator.CodeRequired},
+		"/lines":         {validator.CodeRequired},
+		"/customer/name": {validator.CodeRequired},
+	}, codesByPath(result))
+}
+
+func TestFromJSONSchema_WhenSchemasAreCombined_ShouldApplyAllOfAnyOfOneOfAndNot(t *testing.T) {
+	// Arrange
+	v := schema.MustFromJSONSchema[any]([]byte(`{
+		"type": "object",
+		"properties": {
+			"all": {"allOf": [{"minimum": 1}, {"maximum": 5}]},
+			"any": {"anyOf": [{"type": "string"}, {"type": "integer"}]},
+			"one": {"oneOf": [{"type": "integer"}, {"minimum": 0}]},
+			"not": {"not": {"const": "admin"}}
+		}
+	}`))
+
+	// Act
+	invalid := v.Validate(decode(t, `{"all": 7, "any": true, "one": 3, "not": "admin"}`))
+	valid := v.Validate(decode(t, `{"all": 3, "any": 2, "one": -1, "not": "user"}`))
+
+	// Assert
+	assert.Equal(t, map[string][]string{
+		"/all": {rules.CodeMax},
+		"/any": {schema.CodeAnyOf},
+		"/one": {schema.CodeExactlyOneOf},
+		"/not": {schema.CodeNot},
+	}, codesByPath(invalid))
+	assert.Equal(t, 2, invalid.FailuresFor("/one")[0].Params["matches"])
+	assert.True(t, valid.IsSuccess(), valid.Error())
+}
+
+func TestFromJSONSchema_WhenReferencesAreRecursive_ShouldValidateAllLevels(t *testing.T) {
+	// Arrange
+	v := schema.MustFromJSONSchema[any]([]byte(`{
+		"$defs": {
+			"node": {
+				"type": "object",
+				"required": ["name"],
+				"properties": {
+					"name": {"type": "string"},
+					"children": {"type": "array", "items": {"$ref": "#/$defs/node"}}
+				}
+			}
+		},
+		"$ref": "#/$defs/node"
+	}`))
+
+	// Act
+	result := v.Validate(decode(t, `{"name": "root", "children": [{"name": "a", "children": [{}]}]}`))
+
+	// Assert
+	assert.Equal(t, map[string][]string{
+		"/children/0/children/0/name": {validator.CodeRequired},
+	}, codesByPath(result))
+}
+
+func TestFromJSONSchema_WhenReferencesAreCircular_ShouldReturnError(t *testing.T) {
+	// Arrange
+	doc := `{
+		"$defs": {
+			"a": {"$ref": "#/$defs/b"},
+			"b": {"$ref": "#/$defs/a"}
+		},
+		"$ref": "#/$defs/a"
+	}`
+
+	// Act
+	v, err := schema.FromJSONSchema[any]([]byte(doc))
+
+	// Assert
+	assert.Nil(t, v)
+	var schemaErr *schema.Error
+	if assert.True(t, errors.As(err, &schemaErr), err) {
+		assert.Equal(t, "/$defs/a", schemaErr.Pointer)
+		assert.Equal(t, "circular $ref: #/$defs/a -> #/$defs/b -> #/$defs/a", schemaErr.Msg)
+	}
+}
+
+func TestFromJSONSchema_WhenValueIsNotAnObject_ShouldCheckItsType(t *testing.T) {
+	// Arrange
+	v := schema.MustFromJSONSchema[any]([]byte(`{"type": "array", "items": {"type": "integer"}}`))
+
+	// Act
+	result := v.Validate(map[string]any{"a": 1})
+	goSlice := v.Validate([]int{1, 2})
+	var nilSlice []any
+	null := v.Validate(nilSlice)
+
+	// Assert
+	assert.Equal(t, []string{schema.CodeType}, result.Codes())
+	assert.Equal(t, "", result.Failures()[0].Path)
+	assert.True(t, goSlice.IsSuccess(), goSlice.Error())
+	assert.Equal(t, []string{schema.CodeType}, null.Codes())
+}
+
+func TestFromJSONSchema_WhenDocumentIsInvalid_ShouldReturnError(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		pointer string
+	}{
+		{name: "syntax", doc: `{"type": `, pointer: ""},
+		{name: "not a schema", doc: `{"properties": {"a": 1}}`, pointer: "/properties/a"},
+		{name: "unknown type", doc: `{"type": "date"}`, pointer: "/type"},
+		{name: "negative length", doc: `{"minLength": -1}`, pointer: "/minLength"},
+		{name: "invalid pattern", doc: `{"pattern": "(?<=a)b"}`, pointer: "/pattern"},
+		{name: "remote reference", doc: `{"$ref": "other.json#/a"}`, pointer: "/$ref"},
+		{name: "missing reference", doc: `{"$ref": "#/$defs/missing"}`, pointer: "/$ref"},
+		{name: "empty anyOf", doc: `{"anyOf": []}`, pointer: "/anyOf"},
+		{name: "self reference", doc: `{"type": "string", "$ref": "#"}`, pointer: ""},
+		{name: "circular allOf", doc: `{"$defs": {"a": {"allOf": [{"$ref": "#/$defs/a"}]}}, "properties": {"x": {"$ref": "#/$defs/a"}}}`, pointer: "/$defs/a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := schema.FromJSONSchema[any]([]byte(tt.doc))
+
+			// Assert
+			var schemaErr *schema.Error
+			if assert.True(t, errors.As(err, &schemaErr), err) {
+				assert.Equal(t, tt.pointer, schemaErr.Pointer)
+			}
+		})
+	}
+}
+
+func TestFromJSONSchema_WhenSchemaIsExported_ShouldAcceptTheSameValues(t *testing.T) {
+	// Arrange
+	doc, err := schema.Marshal(validatorForAddress())
+	if !assert.NoError(t, err) {
+		return
+	}
+	v := schema.MustFromJSONSchema[map[string]any](doc)
+
+	// Act
+	valid := v.Validate(decode(t, `{"city": "Girona", "country": "ES"}`))
+	invalid := v.Validate(decode(t, `{"city": "", "country": "ESP"}`))
+
+	// Assert
+	assert.True(t, valid.IsSuccess(), valid.Error())
+	assert.Equal(t, map[string][]string{
+		"/city":    {validator.CodeMinLength},
+		"/country": {validator.CodeMaxLength},
+	}, codesByPath(invalid))
+}
+
+func validatorForAddress() validator.Validator[address] {
+	v := validator.New[address]()
+	validator.RuleFor(v, "city", func(a address) string { return a.City }).
+		NotEmpty()
+	validator.RuleFor(v, "country", func(a address) string { return a.Country }).
+		Check(rules.Length(2))
+	return v
+}
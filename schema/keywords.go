@@ -0,0 +1,284 @@
+package schema
+
+import (
+	"math"
+	"reflect"
+	"regexp"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/cgxarrie-go/validator/rules"
+)
+
+var formats = map[string]string{
+	rules.CodeEmail:        "email",
+	rules.CodeURL:          "uri",
+	rules.CodeURLReference: "uri-reference",
+	rules.CodeUUID:         "uuid",
+	rules.CodeIPv4:         "ipv4",
+	rules.CodeIPv6:         "ipv6",
+	rules.CodeHostname:     "hostname",
+}
+
+// applyRule adds the keywords of rule to s, and returns false when the rule
+// has no schema equivalent for the type of s
+func (s *Schema) applyRule(rule validator.RuleDescription) bool {
+	if format, ok := formats[rule.Code]; ok && s.isString() {
+		s.addFormat(format)
+		return true
+	}
+
+	switch rule.Code {
+	case validator.CodeRequired, rules.CodeNotEmpty:
+		s.nonEmpty()
+		return true
+
+	case rules.CodeMinLength, rules.CodeMinCount:
+		return s.setCount(rule.Params["min"], true)
+	case rules.CodeMaxLength, rules.CodeMaxCount:
+		return s.setCount(rule.Params["max"], false)
+	case rules.CodeLength:
+		return s.setCount(rule.Params["length"], true) && s.setCount(rule.Params["length"], false)
+
+	case rules.CodePattern:
+		return s.addPattern(stringParam(rule.Params["pattern"]))
+	case rules.CodePrefix:
+		return s.addPattern("^" + regexp.QuoteMeta(stringParam(rule.Params["prefix"])))
+	case rules.CodeSuffix:
+		return s.addPattern(regexp.QuoteMeta(stringParam(rule.Params["suffix"])) + "$")
+	case rules.CodeContains:
+		return s.addPattern(regexp.QuoteMeta(stringParam(rule.Params["substring"])))
+	case rules.CodeNotBlank:
+		return s.addPattern(`\S`)
+
+	case rules.CodeOneOf:
+		values, ok := listParam(rule.Params["values"])
+		if !ok {
+			return false
+		}
+		s.Enum = values
+		return true
+	case rules.CodeEqual:
+		if value, ok := rule.Params["value"]; ok {
+			s.Const = value
+			return true
+		}
+		return false
+	case rules.CodeNotEqual:
+		if value, ok := rule.Params["value"]; ok {
+			s.addNot(&Schema{Const: value})
+			return true
+		}
+		return false
+
+	case rules.CodeIP:
+		if !s.isString() {
+			return false
+		}
+		s.AnyOf = append(s.AnyOf, &Schema{Format: "ipv4"}, &Schema{Format: "ipv6"})
+		return true
+	case rules.CodeUnique:
+		if s.Type != "array" {
+			return false
+		}
+		s.UniqueItems = true
+		return true
+	}
+
+	if !s.isNumber() {
+		return false
+	}
+	return s.applyNumberRule(rule)
+}
+
+// applyNumberRule adds the keywords of the rules on numbers to s
+func (s *Schema) applyNumberRule(rule validator.RuleDescription) bool {
+	min, max, value := rule.Params["min"], rule.Params["max"], rule.Params["value"]
+
+	switch rule.Code {
+	case rules.CodeMin:
+		s.Minimum = stricter(s.Minimum, min, true)
+	case rules.CodeMax:
+		s.Maximum = stricter(s.Maximum, max, false)
+	case rules.CodeBetween, rules.CodePort:
+		s.Minimum = stricter(s.Minimum, min, true)
+		s.Maximum = stricter(s.Maximum, max, false)
+	case rules.CodeExclusiveBetween:
+		s.ExclusiveMinimum = stricter(s.ExclusiveMinimum, min, true)
+		s.ExclusiveMaximum = stricter(s.ExclusiveMaximum, max, false)
+	case rules.CodeGreaterThan:
+		s.ExclusiveMinimum = stricter(s.ExclusiveMinimum, value, true)
+	case rules.CodeLessThan:
+		s.ExclusiveMaximum = stricter(s.ExclusiveMaximum, value, false)
+	case rules.CodePositive:
+		s.ExclusiveMinimum = stricter(s.ExclusiveMinimum, 0, true)
+	case rules.CodeNegative:
+		s.ExclusiveMaximum = stricter(s.ExclusiveMaximum, 0, false)
+	case rules.CodeNonZero:
+		s.addNot(&Schema{Const: 0})
+	case rules.CodeMultipleOf:
+		s.MultipleOf = value
+	case rules.CodeDecimalPlaces:
+		places, ok := rule.Params["places"].(int)
+		if !ok {
+			return false
+		}
+		s.MultipleOf = math.Pow10(-places)
+	case rules.CodeFinite, rules.CodeNotNaN:
+		// JSON numbers are always finite
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *Schema) isString() bool {
+	return s.Type == "string" || s.Type == ""
+}
+
+func (s *Schema) isNumber() bool {
+	return s.Type == "integer" || s.Type == "number"
+}
+
+// nonEmpty excludes the empty values: zero numbers, false, and strings,
+// arrays and maps with no characters, items or properties
+func (s *Schema) nonEmpty() {
+	one := 1
+	switch s.Type {
+	case "integer", "number":
+		s.addNot(&Schema{Const: 0})
+	case "boolean":
+		s.Const = true
+	case "string":
+		s.MinLength = maxCount(s.MinLength, &one)
+	case "array":
+		s.MinItems = maxCount(s.MinItems, &one)
+	case "object":
+		if s.Properties == nil {
+			s.MinProperties = maxCount(s.MinProperties, &one)
+		}
+	}
+}
+
+// setCount sets the minimum or maximum length of a string, or the number of
+// items or properties of an array or object
+func (s *Schema) setCount(param any, min bool) bool {
+	n, ok := param.(int)
+	if !ok {
+		return false
+	}
+
+	var current **int
+	switch s.Type {
+	case "string":
+		current = &s.MaxLength
+		if min {
+			current = &s.MinLength
+		}
+	case "array":
+		current = &s.MaxItems
+		if min {
+			current = &s.MinItems
+		}
+	case "object":
+		current = &s.MaxProperties
+		if min {
+			current = &s.MinProperties
+		}
+	default:
+		return false
+	}
+
+	if min {
+		*current = maxCount(*current, &n)
+	} else if *current == nil || n < **current {
+		*current = &n
+	}
+	return true
+}
+
+func maxCount(current, n *int) *int {
+	if current == nil || *n > *current {
+		return n
+	}
+	return current
+}
+
+// addPattern adds a pattern to s, in allOf when s already has one
+func (s *Schema) addPattern(pattern string) bool {
+	if !s.isString() {
+		return false
+	}
+
+	if s.Pattern == "" {
+		s.Pattern = pattern
+		return true
+	}
+	s.AllOf = append(s.AllOf, &Schema{Pattern: pattern})
+	return true
+}
+
+func (s *Schema) addFormat(format string) {
+	if s.Format == "" {
+		s.Format = format
+		return
+	}
+	s.AllOf = append(s.AllOf, &Schema{Format: format})
+}
+
+func (s *Schema) addNot(not *Schema) {
+	if s.Not == nil {
+		s.Not = not
+		return
+	}
+	s.AllOf = append(s.AllOf, &Schema{Not: not})
+}
+
+// stricter returns the stricter of the current bound and value, the greater
+// one for minimums
+func stricter(current, value any, min bool) any {
+	if current == nil {
+		return value
+	}
+
+	c, ok1 := toFloat(current)
+	v, ok2 := toFloat(value)
+	if !ok1 || !ok2 {
+		return value
+	}
+	if (min && v > c) || (!min && v < c) {
+		return value
+	}
+	return current
+}
+
+func toFloat(value any) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+func stringParam(value any) string {
+	s, _ := value.(string)
+	return s
+}
+
+// listParam returns the elements of a slice parameter
+func listParam(value any) ([]any, bool) {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice {
+		return nil, false
+	}
+
+	values := make([]any, rv.Len())
+	for i := range values {
+		values[i] = rv.Index(i).Interface()
+	}
+	return values, true
+}
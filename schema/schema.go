@@ -0,0 +1,234 @@
+// Package schema converts validators to and from JSON Schema (draft 2020-12)
+// documents.
+//
+// The schema of a validator for T is built from the type of T and from the
+// description of the validator, see validator.Describe. Fields get the type of
+// their Go type, and the known rules are mapped to their schema keywords, e.g.
+// a max_length rule to maxLength or a one_of rule to enum.
+//
+// Steps added with AddStep or AddStepContext, validators that cannot describe
+// themselves, and rules with no schema equivalent, such as the Must rules of a
+// rule chain, cannot be exported. They are listed in the "x-opaque" keyword of
+// the schema they apply to, so the document shows what it leaves out.
+//
+// The other way round, FromJSONSchema builds validators for untyped payloads
+// from JSON Schema documents, reporting failures with JSON Pointer paths
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/cgxarrie-go/validator/tags"
+)
+
+// Draft is the JSON Schema dialect of the exported documents
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// OpaqueStep is the entry of "x-opaque" for a step or a validator whose
+// constraints are not known
+const OpaqueStep = "step"
+
+// Schema is a JSON Schema document, or a subschema of it
+type Schema struct {
+	Schema string `json:"$schema,omitempty"`
+	Type   string `json:"type,omitempty"`
+	Format string `json:"format,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	PropertyNames        *Schema            `json:"propertyNames,omitempty"`
+	MinProperties        *int               `json:"minProperties,omitempty"`
+	MaxProperties        *int               `json:"maxProperties,omitempty"`
+
+	Items       *Schema `json:"items,omitempty"`
+	MinItems    *int    `json:"minItems,omitempty"`
+	MaxItems    *int    `json:"maxItems,omitempty"`
+	UniqueItems bool    `json:"uniqueItems,omitempty"`
+
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+
+	Minimum          any `json:"minimum,omitempty"`
+	Maximum          any `json:"maximum,omitempty"`
+	ExclusiveMinimum any `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum any `json:"exclusiveMaximum,omitempty"`
+	MultipleOf       any `json:"multipleOf,omitempty"`
+
+	Enum  []any     `json:"enum,omitempty"`
+	Const any       `json:"const,omitempty"`
+	Not   *Schema   `json:"not,omitempty"`
+	AllOf []*Schema `json:"allOf,omitempty"`
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+
+	// Opaque lists the constraints that cannot be expressed in the schema: the
+	// codes of the rules with no schema equivalent and OpaqueStep for every
+	// step or validator that cannot describe itself
+	Opaque []string `json:"x-opaque,omitempty"`
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Export returns the JSON Schema of the values checked by v
+func Export[T any](v validator.Validator[T]) *Schema {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+
+	s := forType(t, make(map[reflect.Type]bool))
+	s.Schema = Draft
+	s.apply(validator.Describe(v))
+	return s
+}
+
+// Marshal returns the JSON Schema of the values checked by v, as an indented
+// JSON document
+func Marshal[T any](v validator.Validator[T]) ([]byte, error) {
+	return json.MarshalIndent(Export(v), "", "  ")
+}
+
+// forType returns the schema of the values of type t. Structs that are being
+// built, found again in a recursive type, are only described as objects
+func forType(t reflect.Type, building map[reflect.Type]bool) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: forType(t.Elem(), building)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: forType(t.Elem(), building)}
+	case reflect.Struct:
+		if t == timeType {
+			return &Schema{Type: "string", Format: "date-time"}
+		}
+
+		s := &Schema{Type: "object"}
+		if building[t] {
+			return s
+		}
+		building[t] = true
+		defer delete(building, t)
+
+		s.Properties = make(map[string]*Schema)
+		addProperties(s, t, building)
+		return s
+	}
+
+	return &Schema{}
+}
+
+// addProperties adds the exported fields of the struct type t to the
+// properties of s, with the names used in the failure paths
+func addProperties(s *Schema, t reflect.Type, building map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() || sf.Tag.Get("json") == "-" {
+			continue
+		}
+
+		name := tags.FieldName(sf)
+		if name != "" {
+			s.Properties[name] = forType(sf.Type, building)
+			continue
+		}
+
+		embedded := sf.Type
+		for embedded.Kind() == reflect.Pointer {
+			embedded = embedded.Elem()
+		}
+		if embedded.Kind() == reflect.Struct {
+			addProperties(s, embedded, building)
+		}
+	}
+}
+
+// apply adds the constraints of d to s
+func (s *Schema) apply(d validator.Description) {
+	for _, rule := range d.Rules {
+		if !s.applyRule(rule) {
+			s.Opaque = append(s.Opaque, rule.Code)
+		}
+	}
+
+	for _, field := range d.Fields {
+		s.applyField(field)
+	}
+
+	if d.Elements != nil {
+		elements := s.Items
+		if s.Type == "object" {
+			elements = s.AdditionalProperties
+		}
+		if elements == nil {
+			elements = &Schema{}
+			if s.Type == "object" {
+				s.AdditionalProperties = elements
+			} else {
+				s.Items = elements
+			}
+		}
+		elements.apply(*d.Elements)
+	}
+
+	if d.Keys != nil {
+		if s.PropertyNames == nil {
+			s.PropertyNames = &Schema{Type: "string"}
+		}
+		s.PropertyNames.apply(*d.Keys)
+	}
+
+	for i := 0; i < d.Opaque; i++ {
+		s.Opaque = append(s.Opaque, OpaqueStep)
+	}
+}
+
+// applyField adds the constraints of field to its property, which is added
+// when the type of s has no field with its name
+func (s *Schema) applyField(field validator.FieldDescription) {
+	name := field.Name
+	if name == "" {
+		s.apply(field.Description)
+		return
+	}
+
+	if s.Properties == nil {
+		s.Properties = make(map[string]*Schema)
+	}
+	property, ok := s.Properties[name]
+	if !ok {
+		property = &Schema{}
+		s.Properties[name] = property
+	}
+
+	if field.Required {
+		if !contains(s.Required, name) {
+			s.Required = append(s.Required, name)
+			sort.Strings(s.Required)
+		}
+		property.nonEmpty()
+	}
+	property.apply(field.Description)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
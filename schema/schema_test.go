@@ -0,0 +1,190 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/cgxarrie-go/validator/rules"
+	"github.com/cgxarrie-go/validator/ruleset"
+	"github.com/cgxarrie-go/validator/schema"
+	"github.com/cgxarrie-go/validator/tags"
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	City    string `json:"city" validate:"required,max=50"`
+	Country string `json:"country" validate:"required,len=2,uppercase"`
+}
+
+type customer struct {
+	Name    string            `json:"name" validate:"required,min=2,max=50"`
+	Email   string            `json:"email" validate:"omitempty,email"`
+	Age     int               `json:"age" validate:"gte=18,lte=130"`
+	Plan    string            `json:"plan" validate:"oneof=free pro"`
+	Level   int               `json:"level" validate:"oneof=1 2 3"`
+	Code    string            `json:"code" validate:"pattern=^[A-Z]{3}$,prefix=X."`
+	Tags    []string          `json:"tags" validate:"max=5,unique,dive,required,alphanum"`
+	Labels  map[string]string `json:"labels" validate:"dive,max=10"`
+	Address *address          `json:"address" validate:"required"`
+	Notes   string            `json:"-"`
+}
+
+type node struct {
+	Name     string  `json:"name" validate:"required"`
+	Children []*node `json:"children" validate:"dive"`
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func TestExport_WhenValidatorIsBuiltFromTags_ShouldMapRulesToKeywords(t *testing.T) {
+	// Act
+	s := schema.Export(tags.MustFromTags[customer]())
+
+	// Assert
+	assert.Equal(t, schema.Draft, s.Schema)
+	assert.Equal(t, "object", s.Type)
+	assert.Equal(t, []string{"address", "name"}, s.Required)
+	assert.NotContains(t, s.Properties, "Notes")
+
+	name := s.Properties["name"]
+	assert.Equal(t, "string", name.Type)
+	assert.Equal(t, intPtr(2), name.MinLength)
+	assert.Equal(t, intPtr(50), name.MaxLength)
+
+	assert.Equal(t, "email", s.Properties["email"].Format)
+
+	age := s.Properties["age"]
+	assert.Equal(t, "integer", age.Type)
+	assert.EqualValues(t, 18, age.Minimum)
+	assert.EqualValues(t, 130, age.Maximum)
+
+	assert.Equal(t, []any{"free", "pro"}, s.Properties["plan"].Enum)
+	assert.Equal(t, []any{int64(1), int64(2), int64(3)}, s.Properties["level"].Enum)
+
+	code := s.Properties["code"]
+	assert.Equal(t, "^[A-Z]{3}$", code.Pattern)
+	if assert.Len(t, code.AllOf, 1) {
+		assert.Equal(t, `^X\.`, code.AllOf[0].Pattern)
+	}
+
+	tagsSchema := s.Properties["tags"]
+	assert.Equal(t, "array", tagsSchema.Type)
+	assert.Equal(t, intPtr(5), tagsSchema.MaxItems)
+	assert.True(t, tagsSchema.UniqueItems)
+	assert.Equal(t, intPtr(1), tagsSchema.Items.MinLength)
+	assert.Equal(t, []string{rules.CodeAlphanumeric}, tagsSchema.Items.Opaque)
+
+	labels := s.Properties["labels"]
+	assert.Equal(t, "object", labels.Type)
+	assert.Equal(t, intPtr(10), labels.AdditionalProperties.MaxLength)
+
+	addr := s.Properties["address"]
+	assert.Equal(t, []string{"city", "country"}, addr.Required)
+	country := addr.Properties["country"]
+	assert.Equal(t, intPtr(2), country.MinLength)
+	assert.Equal(t, intPtr(2), country.MaxLength)
+	assert.Equal(t, []string{rules.CodeUppercase}, country.Opaque)
+}
+
+func TestExport_WhenValidatorUsesRuleChains_ShouldDescribeFieldsAndElements(t *testing.T) {
+	// Arrange
+	v := validator.New[customer]()
+	validator.RuleFor(v, "name", func(c customer) string { return c.Name }).
+		NotEmpty().
+		Matches(regexp.MustCompile(`^\w+$`))
+	validator.RuleFor(v, "age", func(c customer) int { return c.Age }).
+		Check(rules.Between(18, 130))
+	validator.ForEach(v, "tags", func(c customer) []string { return c.Tags }).
+		MaxCount(3).
+		CheckAll(rules.Unique[string]()).
+		Check(rules.MaxLength(10))
+	validator.ForEachKey(v, "labels", func(c customer) map[string]string { return c.Labels }).
+		Check(rules.Lowercase(), rules.MinLength(1))
+
+	// Act
+	s := schema.Export[customer](v)
+
+	// Assert
+	assert.Empty(t, s.Opaque)
+	assert.Equal(t, intPtr(1), s.Properties["name"].MinLength)
+	assert.Equal(t, `^\w+$`, s.Properties["name"].Pattern)
+	assert.EqualValues(t, 18, s.Properties["age"].Minimum)
+	assert.EqualValues(t, 130, s.Properties["age"].Maximum)
+	assert.Equal(t, intPtr(3), s.Properties["tags"].MaxItems)
+	assert.True(t, s.Properties["tags"].UniqueItems)
+	assert.Equal(t, intPtr(10), s.Properties["tags"].Items.MaxLength)
+	assert.Equal(t, intPtr(1), s.Properties["labels"].PropertyNames.MinLength)
+	assert.Equal(t, []string{rules.CodeLowercase}, s.Properties["labels"].PropertyNames.Opaque)
+}
+
+func TestExport_WhenStepsAreCustom_ShouldFlagThemAsOpaque(t *testing.T) {
+	// Arrange
+	v := validator.New[customer]()
+	v.AddStep(func(customer) error { return nil })
+	validator.RuleFor(v, "name", func(c customer) string { return c.Name }).
+		Must(func(s string) bool { return s != "admin" }, "is reserved")
+	v.AddValidator(validator.NewConditional[string, customer]())
+
+	// Act
+	s := schema.Export[customer](v)
+
+	// Assert
+	assert.Equal(t, []string{schema.OpaqueStep, schema.OpaqueStep}, s.Opaque)
+	assert.Equal(t, []string{validator.CodePredicate}, s.Properties["name"].Opaque)
+}
+
+func TestExport_WhenTypeIsRecursive_ShouldStopAtTheRecursion(t *testing.T) {
+	// Act
+	s := schema.Export(tags.MustFromTags[node]())
+
+	// Assert
+	children := s.Properties["children"]
+	assert.Equal(t, "array", children.Type)
+	assert.Equal(t, "object", children.Items.Type)
+	assert.Nil(t, children.Items.Properties)
+	assert.Equal(t, []string{schema.OpaqueStep}, children.Items.Opaque)
+}
+
+func TestExport_WhenValidatorIsBuiltFromARuleSet_ShouldDescribeItsRules(t *testing.T) {
+	// Arrange
+	rs, err := ruleset.Parse([]byte(`
+fields:
+  name: [required, max=10]
+  address.country: [required, oneof=ES FR]
+`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// Act
+	s := schema.Export(ruleset.MustBuild[customer](rs))
+	payload := schema.Export(ruleset.MustBuild[map[string]any](rs))
+
+	// Assert
+	assert.Equal(t, []string{"name"}, s.Required)
+	assert.Equal(t, intPtr(10), s.Properties["name"].MaxLength)
+	assert.Equal(t, []string{"country"}, s.Properties["address"].Required)
+	assert.Equal(t, []any{"ES", "FR"}, s.Properties["address"].Properties["country"].Enum)
+	assert.Equal(t, []string{schema.OpaqueStep, schema.OpaqueStep}, payload.Opaque)
+}
+
+func TestMarshal_WhenCalled_ShouldReturnTheSchemaDocument(t *testing.T) {
+	// Act
+	data, err := schema.Marshal(tags.MustFromTags[address]())
+
+	// Assert
+	assert.NoError(t, err)
+	doc := map[string]any{}
+	assert.NoError(t, json.Unmarshal(data, &doc))
+	assert.Equal(t, schema.Draft, doc["$schema"])
+	assert.Equal(t, []any{"city", "country"}, doc["required"])
+	assert.Equal(t, map[string]any{
+		"type":      "string",
+		"minLength": float64(1),
+		"maxLength": float64(50),
+	}, doc["properties"].(map[string]any)["city"])
+}
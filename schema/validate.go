@@ -0,0 +1,266 @@
+package schema
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/cgxarrie-go/validator/rules"
+)
+
+// validate adds to result the failures of v, found at path, against the
+// schema
+func (n *node) validate(v any, path string, result *validator.Result) {
+	if n.allow != nil {
+		if !*n.allow {
+			result.AddFailure(failure(path, v, CodeNotAllowed, "is not allowed", nil))
+		}
+		return
+	}
+
+	if n.ref != nil {
+		n.ref.validate(v, path, result)
+	}
+
+	if len(n.types) > 0 && !n.hasType(v) {
+		msg := fmt.Sprintf("must be of type %s", strings.Join(n.types, " or "))
+		result.AddFailure(failure(path, v, CodeType, msg, map[string]any{"types": n.types}))
+		return
+	}
+
+	n.validateValue(v, path, result)
+
+	switch typeOf(v) {
+	case typeString:
+		n.validateString(text(v), v, path, result)
+	case typeNumber:
+		num, _ := number(v)
+		n.validateNumber(num, v, path, result)
+	case typeArray:
+		n.validateArray(elements(v), v, path, result)
+	case typeObject:
+		names, props := members(v)
+		n.validateObject(names, props, v, path, result)
+	}
+
+	n.validateSubschemas(v, path, result)
+}
+
+func (n *node) hasType(v any) bool {
+	for _, t := range n.types {
+		if hasType(v, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateValue checks the keywords applying to values of any type
+func (n *node) validateValue(v any, path string, result *validator.Result) {
+	if n.enum != nil {
+		found := false
+		for _, e := range n.enum {
+			if equal(v, e) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			msg := fmt.Sprintf("must be one of %s", formatValues(n.enum))
+			result.AddFailure(failure(path, v, rules.CodeOneOf, msg, map[string]any{"values": n.enum}))
+		}
+	}
+
+	if n.hasConst && !equal(v, n.constant) {
+		msg := fmt.Sprintf("must be equal to %s", formatValue(n.constant))
+		result.AddFailure(failure(path, v, rules.CodeEqual, msg, map[string]any{"value": n.constant}))
+	}
+}
+
+func (n *node) validateString(s string, v any, path string, result *validator.Result) {
+	length := utf8.RuneCountInString(s)
+
+	if n.minLength != nil && length < *n.minLength {
+		msg := fmt.Sprintf("length must be at least %d", *n.minLength)
+		result.AddFailure(failure(path, v, rules.CodeMinLength, msg, map[string]any{"min": *n.minLength}))
+	}
+	if n.maxLength != nil && length > *n.maxLength {
+		msg := fmt.Sprintf("length must be at most %d", *n.maxLength)
+		result.AddFailure(failure(path, v, rules.CodeMaxLength, msg, map[string]any{"max": *n.maxLength}))
+	}
+	if n.pattern != nil && !n.pattern.MatchString(s) {
+		msg := fmt.Sprintf("must match pattern %s", n.pattern)
+		result.AddFailure(failure(path, v, rules.CodePattern, msg, map[string]any{"pattern": n.pattern.String()}))
+	}
+}
+
+func (n *node) validateNumber(num float64, v any, path string, result *validator.Result) {
+	if n.minimum != nil && num < *n.minimum {
+		msg := fmt.Sprintf("must be greater than or equal to %v", *n.minimum)
+		result.AddFailure(failure(path, v, rules.CodeMin, msg, map[string]any{"min": *n.minimum}))
+	}
+	if n.maximum != nil && num > *n.maximum {
+		msg := fmt.Sprintf("must be less than or equal to %v", *n.maximum)
+		result.AddFailure(failure(path, v, rules.CodeMax, msg, map[string]any{"max": *n.maximum}))
+	}
+	if n.exclusiveMinimum != nil && num <= *n.exclusiveMinimum {
+		msg := fmt.Sprintf("must be greater than %v", *n.exclusiveMinimum)
+		result.AddFailure(failure(path, v, rules.CodeGreaterThan, msg, map[string]any{"value": *n.exclusiveMinimum}))
+	}
+	if n.exclusiveMaximum != nil && num >= *n.exclusiveMaximum {
+		msg := fmt.Sprintf("must be less than %v", *n.exclusiveMaximum)
+		result.AddFailure(failure(path, v, rules.CodeLessThan, msg, map[string]any{"value": *n.exclusiveMaximum}))
+	}
+	if n.multipleOf != nil && !isMultiple(num, *n.multipleOf) {
+		msg := fmt.Sprintf("must be a multiple of %v", *n.multipleOf)
+		result.AddFailure(failure(path, v, rules.CodeMultipleOf, msg, map[string]any{"value": *n.multipleOf}))
+	}
+}
+
+// isMultiple reports whether num is a multiple of m, allowing for the
+// rounding errors of decimal multiples such as 0.01
+func isMultiple(num, m float64) bool {
+	q := num / m
+	return math.Abs(q-math.Round(q)) < 1e-9
+}
+
+func (n *node) validateArray(items []any, v any, path string, result *validator.Result) {
+	if n.minItems != nil && len(items) < *n.minItems {
+		msg := fmt.Sprintf("must contain at least %d elements", *n.minItems)
+		result.AddFailure(failure(path, v, rules.CodeMinCount, msg, map[string]any{"min": *n.minItems}))
+	}
+	if n.maxItems != nil && len(items) > *n.maxItems {
+		msg := fmt.Sprintf("must contain at most %d elements", *n.maxItems)
+		result.AddFailure(failure(path, v, rules.CodeMaxCount, msg, map[string]any{"max": *n.maxItems}))
+	}
+
+	if n.uniqueItems {
+		for i := range items {
+			for j := 0; j < i; j++ {
+				if equal(items[i], items[j]) {
+					msg := fmt.Sprintf("must be unique, duplicates element %d", j)
+					result.AddFailure(failure(index(path, i), items[i], rules.CodeUnique, msg, map[string]any{"index": j}))
+					break
+				}
+			}
+		}
+	}
+
+	for i, item := range items {
+		switch {
+		case i < len(n.prefixItems):
+			n.prefixItems[i].validate(item, index(path, i), result)
+		case n.items != nil:
+			n.items.validate(item, index(path, i), result)
+		}
+	}
+}
+
+func (n *node) validateObject(names []string, props map[string]any, v any, path string, result *validator.Result) {
+	if n.minProperties != nil && len(names) < *n.minProperties {
+		msg := fmt.Sprintf("must contain at least %d properties", *n.minProperties)
+		f := failure(path, v, rules.CodeMinCount, msg, map[string]any{"min": *n.minProperties})
+		f.Key = KeyMinProperties
+		result.AddFailure(f)
+	}
+	if n.maxProperties != nil && len(names) > *n.maxProperties {
+		msg := fmt.Sprintf("must contain at most %d properties", *n.maxProperties)
+		f := failure(path, v, rules.CodeMaxCount, msg, map[string]any{"max": *n.maxProperties})
+		f.Key = KeyMaxProperties
+		result.AddFailure(f)
+	}
+
+	for _, name := range n.required {
+		if _, ok := props[name]; !ok {
+			result.AddFailure(failure(pointer(path, name), nil, validator.CodeRequired, "is required", nil))
+		}
+	}
+
+	for _, name := range names {
+		prop, propPath := props[name], pointer(path, name)
+		matched := false
+
+		if schema, ok := n.properties[name]; ok {
+			matched = true
+			schema.validate(prop, propPath, result)
+		}
+		for _, pp := range n.patternProperties {
+			if pp.pattern.MatchString(name) {
+				matched = true
+				pp.schema.validate(prop, propPath, result)
+			}
+		}
+
+		if matched || n.additional == nil {
+			continue
+		}
+		if n.additional.allow != nil && !*n.additional.allow {
+			result.AddFailure(failure(propPath, prop, CodeAdditionalProperty, "is not allowed", nil))
+			continue
+		}
+		n.additional.validate(prop, propPath, result)
+	}
+}
+
+// validateSubschemas checks the allOf, anyOf, oneOf and not keywords
+func (n *node) validateSubschemas(v any, path string, result *validator.Result) {
+	for _, schema := range n.allOf {
+		schema.validate(v, path, result)
+	}
+
+	if n.anyOf != nil && n.matches(n.anyOf, v, path) == 0 {
+		result.AddFailure(failure(path, v, CodeAnyOf, "must match at least one schema", nil))
+	}
+
+	if n.oneOf != nil {
+		if matches := n.matches(n.oneOf, v, path); matches != 1 {
+			msg := fmt.Sprintf("must match exactly one schema, matches %d", matches)
+			result.AddFailure(failure(path, v, CodeExactlyOneOf, msg, map[string]any{"matches": matches}))
+		}
+	}
+
+	if n.not != nil && n.matches([]*node{n.not}, v, path) == 1 {
+		result.AddFailure(failure(path, v, CodeNot, "must not match the schema", nil))
+	}
+}
+
+// matches returns the number of schemas that v matches
+func (n *node) matches(schemas []*node, v any, path string) int {
+	matches := 0
+	for _, schema := range schemas {
+		res := validator.Result{}
+		schema.validate(v, path, &res)
+		if res.IsSuccess() {
+			matches++
+		}
+	}
+	return matches
+}
+
+func failure(path string, v any, code, msg string, params map[string]any) validator.Failure {
+	return validator.Failure{
+		Path:    path,
+		Code:    code,
+		Message: msg,
+		Value:   v,
+		Params:  params,
+	}
+}
+
+// formatValues formats values as a JSON-like list, e.g. ["a", 1]
+func formatValues(values []any) string {
+	formatted := make([]string, len(values))
+	for i, v := range values {
+		formatted[i] = formatValue(v)
+	}
+	return "[" + strings.Join(formatted, ", ") + "]"
+}
+
+func formatValue(v any) string {
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,195 @@
+package schema
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSON types of the values, as named by the type keyword
+const (
+	typeNull    = "null"
+	typeBoolean = "boolean"
+	typeObject  = "object"
+	typeArray   = "array"
+	typeNumber  = "number"
+	typeString  = "string"
+	typeInteger = "integer"
+)
+
+// typeOf returns the JSON type of a value decoded from JSON, or of the Go
+// maps with string keys, slices, strings, numbers and booleans. It returns an
+// empty string for the other values
+func typeOf(v any) string {
+	if v == nil {
+		return typeNull
+	}
+	if _, ok := v.(json.Number); ok {
+		return typeNumber
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Bool:
+		return typeBoolean
+	case reflect.String:
+		return typeString
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return typeNumber
+	case reflect.Slice, reflect.Array:
+		return typeArray
+	case reflect.Map:
+		if rv.Type().Key().Kind() == reflect.String {
+			return typeObject
+		}
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return typeNull
+		}
+		return typeOf(rv.Elem().Interface())
+	}
+	return ""
+}
+
+// hasType reports whether v is of the JSON type t. Integers are the numbers
+// with no fractional part
+func hasType(v any, t string) bool {
+	actual := typeOf(v)
+	if t == typeInteger {
+		if actual != typeNumber {
+			return false
+		}
+		n, ok := number(v)
+		return ok && n == math.Trunc(n) && !math.IsInf(n, 0)
+	}
+	return actual == t
+}
+
+func deref(v any) any {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil
+	}
+	return rv.Interface()
+}
+
+// number returns the value of a number
+func number(v any) (float64, bool) {
+	v = deref(v)
+	if n, ok := v.(json.Number); ok {
+		f, err := n.Float64()
+		return f, err == nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// text returns the value of a string
+func text(v any) string {
+	return reflect.ValueOf(deref(v)).String()
+}
+
+// elements returns the elements of an array
+func elements(v any) []any {
+	rv := reflect.ValueOf(deref(v))
+	items := make([]any, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items
+}
+
+// members returns the properties of an object, sorted by name
+func members(v any) ([]string, map[string]any) {
+	rv := reflect.ValueOf(deref(v))
+	names := make([]string, 0, rv.Len())
+	props := make(map[string]any, rv.Len())
+
+	iter := rv.MapRange()
+	for iter.Next() {
+		name := iter.Key().String()
+		names = append(names, name)
+		props[name] = iter.Value().Interface()
+	}
+	sort.Strings(names)
+	return names, props
+}
+
+// equal reports whether a and b are equal JSON values. Numbers are equal when
+// they have the same value, whatever their Go type
+func equal(a, b any) bool {
+	ta, tb := typeOf(a), typeOf(b)
+	if ta != tb {
+		return false
+	}
+
+	switch ta {
+	case typeNull:
+		return true
+	case typeBoolean:
+		return reflect.ValueOf(deref(a)).Bool() == reflect.ValueOf(deref(b)).Bool()
+	case typeString:
+		return text(a) == text(b)
+	case typeNumber:
+		na, _ := number(a)
+		nb, _ := number(b)
+		return na == nb
+	case typeArray:
+		ea, eb := elements(a), elements(b)
+		if len(ea) != len(eb) {
+			return false
+		}
+		for i := range ea {
+			if !equal(ea[i], eb[i]) {
+				return false
+			}
+		}
+		return true
+	case typeObject:
+		na, pa := members(a)
+		nb, pb := members(b)
+		if len(na) != len(nb) {
+			return false
+		}
+		for _, name := range na {
+			other, ok := pb[name]
+			if !ok || !equal(pa[name], other) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// pointer returns the JSON Pointer of the child token of parent
+func pointer(parent string, token string) string {
+	token = strings.ReplaceAll(token, "~", "~0")
+	token = strings.ReplaceAll(token, "/", "~1")
+	return parent + "/" + token
+}
+
+// index returns the JSON Pointer of the element i of parent
+func index(parent string, i int) string {
+	return parent + "/" + strconv.Itoa(i)
+}
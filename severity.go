@@ -0,0 +1,79 @@
+package validator
+
+import "fmt"
+
+// Severity is the level of a failure. Only errors make a validation fail;
+// warnings and infos are reported by Result.Warnings and Result.Infos
+type Severity int
+
+const (
+	// SeverityError is the severity of the failures that make a validation
+	// fail. It is the zero value, so failures are errors unless set otherwise
+	SeverityError Severity = iota
+	// SeverityWarning flags a value that is accepted but should be fixed,
+	// e.g. a weak password
+	SeverityWarning
+	// SeverityInfo reports a remark on an accepted value
+	SeverityInfo
+)
+
+// String returns "error", "warning" or "info"
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// MarshalText implements encoding.TextMarshaler, so severities are encoded
+// as their names
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+type severityRule[F any] struct {
+	Rule[F]
+	severity Severity
+}
+
+// WithSeverity returns a rule reporting the failures of rule with the given
+// severity, e.g. WithSeverity(rules.MinLength(12), SeverityWarning) accepts
+// short values but flags them
+func WithSeverity[F any](rule Rule[F], severity Severity) Rule[F] {
+	return severityRule[F]{Rule: rule, severity: severity}
+}
+
+// Warning returns a rule reporting the failures of rule as warnings
+func Warning[F any](rule Rule[F]) Rule[F] {
+	return WithSeverity(rule, SeverityWarning)
+}
+
+// Info returns a rule reporting the failures of rule as infos
+func Info[F any](rule Rule[F]) Rule[F] {
+	return WithSeverity(rule, SeverityInfo)
+}
+
+func (r severityRule[F]) Check(value F) error {
+	err := r.Rule.Check(value)
+	if err == nil {
+		return nil
+	}
+
+	result := Result{}
+	result.AddFailure(err)
+	return result.withSeverity(r.severity)
+}
+
+// Describe describes the rule when it reports errors. Warnings and infos do
+// not constrain the accepted values, so they are not described
+func (r severityRule[F]) Describe() Description {
+	if r.severity != SeverityError {
+		return Description{}
+	}
+	return Describe(r.Rule)
+}
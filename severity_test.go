@@ -0,0 +1,168 @@
+package validator_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type account struct {
+	User     string
+	Password string
+	Home     *address
+}
+
+func strongPassword() validator.Rule[string] {
+	return validator.NewRule("strong", "is weak", nil, func(s string) bool {
+		return len(s) >= 12
+	})
+}
+
+func Test_Warning_WhenRuleFails_ShouldReportWarningWithoutFailing(t *testing.T) {
+	// Arrange
+	v := validator.New[account]()
+	validator.RuleFor(v, "password", func(a account) string { return a.Password }).
+		NotEmpty().
+		Check(validator.Warning(strongPassword()))
+
+	// Act
+	result := v.Validate(account{Password: "secret"})
+
+	// Assert
+	assert.True(t, result.IsSuccess())
+	assert.True(t, result.HasWarnings())
+	assert.Empty(t, result.Failures())
+	assert.Equal(t, []validator.Failure{{
+		Path:     "password",
+		Code:     "strong",
+		Message:  "is weak",
+		Value:    "secret",
+		Severity: validator.SeverityWarning,
+	}}, result.Warnings())
+}
+
+func Test_Warning_WhenChainBreaksOnFailure_ShouldNotStopAtWarnings(t *testing.T) {
+	// Arrange
+	v := validator.New[account]()
+	validator.RuleFor(v, "password", func(a account) string { return a.Password }).
+		BreakOnFailure().
+		Check(validator.Warning(strongPassword())).
+		MinLen(8)
+	validator.RuleFor(v, "user", func(a account) string { return a.User }).
+		NotEmpty()
+
+	// Act
+	result := v.Validate(account{Password: "secret"})
+
+	// Assert
+	assert.Equal(t, []string{"password: length must be at least 8"}, result.GetFailureMessages())
+	assert.Len(t, result.Warnings(), 1)
+}
+
+func Test_WithSeverity_WhenStepFails_ShouldReportItsErrorsWithTheSeverity(t *testing.T) {
+	// Arrange
+	v := validator.New[account]()
+	validator.RuleFor(v, "user", func(a account) string { return a.User }).
+		WithSeverity(validator.SeverityInfo).
+		NotEmpty()
+	validator.SetValidator(v, "home", func(a account) *address { return a.Home }, newAddressValidator()).
+		WithSeverity(validator.SeverityWarning)
+	v.AddStep(func(account) error { return errors.New("not verified") }).
+		WithSeverity(validator.SeverityWarning)
+
+	// Act
+	result := v.Validate(account{Home: &address{Country: "ES"}})
+
+	// Assert
+	assert.True(t, result.IsSuccess())
+	assert.Equal(t, []string{"home.city: must not be empty", "not verified"}, messages(result.Warnings()))
+	assert.Equal(t, []string{"user: must not be empty"}, messages(result.Infos()))
+}
+
+func Test_Strict_WhenStepsReportWarnings_ShouldPromoteThemToErrors(t *testing.T) {
+	// Arrange
+	v := validator.New[account]().Strict()
+	validator.RuleFor(v, "password", func(a account) string { return a.Password }).
+		Check(validator.Warning(strongPassword()), validator.Info(strongPassword()))
+
+	// Act
+	result := v.Validate(account{Password: "secret"})
+
+	// Assert
+	assert.True(t, result.IsFailure())
+	assert.False(t, result.HasWarnings())
+	assert.Equal(t, validator.SeverityError, result.Failures()[0].Severity)
+	assert.Equal(t, []string{"password: is weak"}, result.GetFailureMessages())
+	assert.Len(t, result.Infos(), 1)
+}
+
+func Test_Strict_WhenValidatorBreaksOnFailure_ShouldStopAtWarnings(t *testing.T) {
+	// Arrange
+	v := validator.New[account]().Strict().BreakOnFailure()
+	validator.RuleFor(v, "password", func(a account) string { return a.Password }).
+		Check(validator.Warning(strongPassword()))
+	validator.RuleFor(v, "user", func(a account) string { return a.User }).
+		NotEmpty()
+
+	// Act
+	result := v.Validate(account{Password: "secret"})
+
+	// Assert
+	assert.Equal(t, []string{"password: is weak"}, result.GetFailureMessages())
+}
+
+func Test_AddFailure_WhenFailureHasSeverity_ShouldTrackItSeparately(t *testing.T) {
+	// Arrange
+	result := validator.Result{}
+
+	// Act
+	result.AddFailure(validator.Failure{Message: "is weak", Severity: validator.SeverityWarning})
+	result.AddFailure(validator.Failure{Message: "was renamed", Severity: validator.SeverityInfo})
+
+	// Assert
+	assert.True(t, result.IsSuccess())
+	assert.Equal(t, "", result.Error())
+	assert.Len(t, result.Warnings(), 1)
+	assert.Len(t, result.Infos(), 1)
+	assert.True(t, result.Strict().IsFailure())
+}
+
+func Test_WithError_WhenStepOnlyReportsWarnings_ShouldKeepThem(t *testing.T) {
+	// Arrange
+	v := validator.New[account]()
+	validator.RuleFor(v, "password", func(a account) string { return a.Password }).
+		Check(validator.Warning(strongPassword())).
+		NotEmpty()
+	v.AddStep(func(account) error {
+		result := validator.Result{}
+		result.AddFailure(validator.Failure{Message: "is weak", Severity: validator.SeverityWarning})
+		return result
+	}).WithError(errors.New("account is not valid"))
+
+	// Act
+	result := v.Validate(account{Password: "secret"})
+
+	// Assert
+	assert.True(t, result.IsSuccess())
+	assert.Equal(t, []string{"password: is weak", "is weak"}, messages(result.Warnings()))
+}
+
+func Test_Severity_WhenMarshaled_ShouldUseItsName(t *testing.T) {
+	// Act
+	data, err := json.Marshal([]validator.Severity{validator.SeverityError, validator.SeverityWarning, validator.SeverityInfo})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, `["error","warning","info"]`, string(data))
+}
+
+func messages(failures []validator.Failure) []string {
+	s := make([]string, len(failures))
+	for i, f := range failures {
+		s[i] = f.Error()
+	}
+	return s
+}
@@ -0,0 +1,24 @@
+package tags
+
+import "github.com/cgxarrie-go/validator"
+
+// Required returns the failure reported for a required value that is nil or
+// zero. It is shared with the code generated by cmd/validatorgen, so both
+// report the same failures
+func Required() validator.Failure {
+	return validator.Failure{
+		Code:    validator.CodeRequired,
+		Message: "is required",
+	}
+}
+
+// Value sets value as the value of err, when err is a validator.Failure. It is
+// shared with the code generated by cmd/validatorgen, so both report the same
+// failures
+func Value(value any, err error) error {
+	if f, ok := err.(validator.Failure); ok {
+		f.Value = value
+		return f
+	}
+	return err
+}
@@ -0,0 +1,308 @@
+package tags
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/cgxarrie-go/validator/internal/keys"
+)
+
+type structPlan struct {
+	fields []*fieldPlan
+}
+
+type fieldPlan struct {
+	index int
+	name  string
+	value *valuePlan
+}
+
+type valuePlan struct {
+	required  bool
+	omitEmpty bool
+	rules     []valueRule
+	nested    *structPlan
+	dive      *valuePlan
+}
+
+func (p *structPlan) validate(rv reflect.Value) validator.Result {
+	result := validator.Result{}
+	for _, field := range p.fields {
+		result.Merge(field.validate(rv))
+	}
+	return result
+}
+
+func (f *fieldPlan) validate(structValue reflect.Value) validator.Result {
+	result := validator.Result{}
+
+	res := f.value.validate(structValue.Field(f.index))
+	if res.IsFailure() {
+		result.AddFailureAt(f.name, res)
+	}
+	return result
+}
+
+func (p *valuePlan) validate(rv reflect.Value) validator.Result {
+	result := validator.Result{}
+
+	if !rv.IsValid() {
+		if p.required {
+			result.AddFailure(Required())
+		}
+		return result
+	}
+
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			if p.required {
+				result.AddFailure(Required())
+			}
+			return result
+		}
+		rv = rv.Elem()
+	}
+
+	empty := isEmpty(rv)
+	if p.required && empty {
+		result.AddFailure(Required())
+		return result
+	}
+	if p.omitEmpty && empty {
+		return result
+	}
+
+	for _, rule := range p.rules {
+		result.AddFailure(rule.check(rv))
+	}
+
+	if p.nested != nil {
+		result.Merge(p.nested.validate(rv))
+	}
+
+	if p.dive != nil {
+		p.validateElements(rv, &result)
+	}
+
+	return result
+}
+
+func (p *valuePlan) validateElements(rv reflect.Value, result *validator.Result) {
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if res := p.dive.validate(rv.Index(i)); res.IsFailure() {
+				result.AddFailureAt(fmt.Sprintf("[%d]", i), res)
+			}
+		}
+	case reflect.Map:
+		mapKeys := rv.MapKeys()
+		keys.Sort(mapKeys)
+
+		for _, key := range mapKeys {
+			if res := p.dive.validate(rv.MapIndex(key)); res.IsFailure() {
+				result.AddFailureAt(fmt.Sprintf("[%v]", key), res)
+			}
+		}
+	}
+}
+
+// describe describes the constraints of the fields of the struct. Structs
+// that are being described, found again in a recursive plan, are opaque
+func (p *structPlan) describe(describing map[*structPlan]bool) validator.Description {
+	d := validator.Description{}
+	if describing[p] {
+		d.Opaque++
+		return d
+	}
+
+	describing[p] = true
+	defer delete(describing, p)
+
+	for _, field := range p.fields {
+		d.Merge(field.describe(describing))
+	}
+	return d
+}
+
+func (f *fieldPlan) describe(describing map[*structPlan]bool) validator.Description {
+	d := f.value.describe(describing)
+	if f.name == "" {
+		return d
+	}
+
+	return validator.Description{Fields: []validator.FieldDescription{{
+		Name:        f.name,
+		Required:    f.value.required,
+		Description: d,
+	}}}
+}
+
+// describe describes the constraints of the value. Required values are
+// described by the field holding them, or as a validator.CodeRequired rule
+// for the elements of a collection
+func (p *valuePlan) describe(describing map[*structPlan]bool) validator.Description {
+	d := validator.Description{}
+	for _, rule := range p.rules {
+		d.Merge(rule.description)
+	}
+
+	if p.nested != nil {
+		d.Merge(p.nested.describe(describing))
+	}
+
+	if p.dive != nil {
+		elements := p.dive.describe(describing)
+		if p.dive.required {
+			elements.Rules = append([]validator.RuleDescription{{Code: validator.CodeRequired}}, elements.Rules...)
+		}
+		d.Elements = &elements
+	}
+
+	return d
+}
+
+func isEmpty(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	}
+	return rv.IsZero()
+}
+
+type compiler struct {
+	compiling map[reflect.Type]bool
+	structs   map[reflect.Type]*structPlan
+	// noNested leaves out the tags of nested structs
+	noNested bool
+}
+
+func newCompiler() *compiler {
+	return &compiler{
+		compiling: make(map[reflect.Type]bool),
+		structs:   make(map[reflect.Type]*structPlan),
+	}
+}
+
+func (c *compiler) compileStruct(t reflect.Type) (*structPlan, error) {
+	if plan, ok := c.structs[t]; ok {
+		return plan, nil
+	}
+	if plan, ok := plans.Load(t); ok {
+		return plan.(*structPlan), nil
+	}
+
+	plan := &structPlan{}
+	c.structs[t] = plan
+	c.compiling[t] = true
+	defer delete(c.compiling, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag := sf.Tag.Get(TagName)
+		if tag == "-" {
+			continue
+		}
+
+		specs, err := Parse(tag)
+		if err != nil {
+			return nil, &Error{Type: t, Field: sf.Name, Msg: err.Error()}
+		}
+
+		value, err := c.compileValue(t, sf, sf.Type, specs)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			continue
+		}
+
+		plan.fields = append(plan.fields, &fieldPlan{
+			index: i,
+			name:  FieldName(sf),
+			value: value,
+		})
+	}
+
+	return plan, nil
+}
+
+func (c *compiler) compileValue(owner reflect.Type, sf reflect.StructField, t reflect.Type, specs []Spec) (*valuePlan, error) {
+	plan := &valuePlan{}
+
+	base := t
+	for base.Kind() == reflect.Pointer {
+		base = base.Elem()
+	}
+
+	for i, spec := range specs {
+		switch spec.Name {
+		case "required":
+			plan.required = true
+		case "omitempty":
+			plan.omitEmpty = true
+		case "dive":
+			switch base.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+			default:
+				return nil, &Error{Type: owner, Field: sf.Name, Rule: spec.Name, Msg: fmt.Sprintf("cannot be applied to %s", base)}
+			}
+
+			elem, err := c.compileValue(owner, sf, base.Elem(), specs[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			plan.dive = elem
+			return c.finish(plan, base)
+		default:
+			rule, err := buildRule(spec, base)
+			if err != nil {
+				return nil, &Error{Type: owner, Field: sf.Name, Rule: spec.Name, Msg: err.Error()}
+			}
+			plan.rules = append(plan.rules, rule)
+		}
+	}
+
+	return c.finish(plan, base)
+}
+
+// finish attaches the plan of base to plan when base is a struct, and returns
+// nil when there is nothing to validate
+func (c *compiler) finish(plan *valuePlan, base reflect.Type) (*valuePlan, error) {
+	if base.Kind() == reflect.Struct && !c.noNested {
+		nested, err := c.compileStruct(base)
+		if err != nil {
+			return nil, err
+		}
+		if len(nested.fields) > 0 || c.compiling[base] {
+			plan.nested = nested
+		}
+	}
+
+	if !plan.required && len(plan.rules) == 0 && plan.nested == nil && plan.dive == nil {
+		return nil, nil
+	}
+	return plan, nil
+}
+
+// FieldName returns the name used in the failure paths for a struct field:
+// the name in its json tag or, when there is none, its Go name. Embedded
+// structs without json name have an empty name, so their fields are reported
+// as fields of the embedding struct
+func FieldName(sf reflect.StructField) string {
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	if name != "" && name != "-" {
+		return name
+	}
+
+	if sf.Anonymous {
+		return ""
+	}
+	return sf.Name
+}
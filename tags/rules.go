@@ -0,0 +1,546 @@
+package tags
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/cgxarrie-go/validator/internal/keys"
+	"github.com/cgxarrie-go/validator/rules"
+)
+
+// valueRule checks a value with a rule of a tag, described by description
+type valueRule struct {
+	check       func(rv reflect.Value) error
+	description validator.Description
+}
+
+type kindClass int
+
+const (
+	classOther kindClass = iota
+	classString
+	classInt
+	classUint
+	classFloat
+	classCollection
+)
+
+func classOf(t reflect.Type) kindClass {
+	switch t.Kind() {
+	case reflect.String:
+		return classString
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return classInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return classUint
+	case reflect.Float32, reflect.Float64:
+		return classFloat
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return classCollection
+	}
+	return classOther
+}
+
+var stringRules = map[string]func() validator.Rule[string]{
+	"notblank":  rules.NotBlank,
+	"alpha":     rules.Alpha,
+	"alphanum":  rules.Alphanumeric,
+	"ascii":     rules.ASCII,
+	"printable": rules.Printable,
+	"lowercase": rules.Lowercase,
+	"uppercase": rules.Uppercase,
+	"trimmed":   rules.Trimmed,
+	"utf8":      rules.ValidUTF8,
+	"email":     rules.Email,
+	"uri":       rules.URLReference,
+	"ip":        rules.IP,
+	"ipv4":      rules.IPv4,
+	"ipv6":      rules.IPv6,
+	"cidr":      rules.CIDR,
+	"hostname":  rules.Hostname,
+	"fqdn":      rules.FQDN,
+	"mac":       rules.MAC,
+}
+
+var errNoParam = errors.New("requires a parameter")
+
+// KnownRule reports whether name is the name of a rule of the validate tags
+func KnownRule(name string) bool {
+	switch name {
+	case "required", "omitempty", "dive",
+		"min", "max", "len", "eq", "ne", "gt", "gte", "lt", "lte",
+		"oneof", "pattern", "prefix", "suffix", "contains", "url", "uuid",
+		"port", "positive", "negative", "nonzero", "multipleof", "finite",
+		"decimals", "unique":
+		return true
+	}
+
+	_, ok := stringRules[name]
+	return ok
+}
+
+// buildRule returns the rule for spec, checking values of type t
+func buildRule(spec Spec, t reflect.Type) (valueRule, error) {
+	class := classOf(t)
+	unsupported := fmt.Errorf("cannot be applied to %s", t)
+
+	if fn, ok := stringRules[spec.Name]; ok {
+		if spec.Param != "" {
+			return valueRule{}, errors.New("does not take a parameter")
+		}
+		if class != classString {
+			return valueRule{}, unsupported
+		}
+		return onString(fn()), nil
+	}
+
+	switch spec.Name {
+	case "min", "max", "len":
+		return buildBound(spec, t, class)
+
+	case "eq", "ne", "gt", "gte", "lt", "lte":
+		return buildComparison(spec, t, class)
+
+	case "oneof":
+		if spec.Param == "" {
+			return valueRule{}, errNoParam
+		}
+		values := strings.Fields(spec.Param)
+		switch class {
+		case classString:
+			return onString(rules.OneOf(values...)), nil
+		case classInt, classUint, classFloat:
+			for i, v := range values {
+				n, err := parseNumber(v, t, class)
+				if err != nil {
+					return valueRule{}, err
+				}
+				values[i] = n
+			}
+			rule := rules.OneOf(values...)
+			return valueRule{
+				check: func(rv reflect.Value) error {
+					return withValue(rv, rule.Check(formatNumber(rv, class)))
+				},
+				description: describe(rules.CodeOneOf, map[string]any{"values": numbers(values, class)}),
+			}, nil
+		}
+		return valueRule{}, unsupported
+
+	case "pattern", "prefix", "suffix", "contains":
+		if spec.Param == "" {
+			return valueRule{}, errNoParam
+		}
+		if class != classString {
+			return valueRule{}, unsupported
+		}
+		switch spec.Name {
+		case "prefix":
+			return onString(rules.HasPrefix(spec.Param)), nil
+		case "suffix":
+			return onString(rules.HasSuffix(spec.Param)), nil
+		case "contains":
+			return onString(rules.Contains(spec.Param)), nil
+		}
+		re, err := regexp.Compile(spec.Param)
+		if err != nil {
+			return valueRule{}, err
+		}
+		return onString(rules.Matches(re)), nil
+
+	case "url":
+		if class != classString {
+			return valueRule{}, unsupported
+		}
+		return onString(rules.URL(strings.Fields(spec.Param)...)), nil
+
+	case "uuid":
+		if class != classString {
+			return valueRule{}, unsupported
+		}
+		versions := make([]int, 0)
+		for _, v := range strings.Fields(spec.Param) {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return valueRule{}, fmt.Errorf("invalid version %q", v)
+			}
+			versions = append(versions, n)
+		}
+		return onString(rules.UUID(versions...)), nil
+
+	case "port":
+		if spec.Param != "" {
+			return valueRule{}, errors.New("does not take a parameter")
+		}
+		// values out of the int range are clamped to an invalid port
+		rule := rules.Port()
+		switch class {
+		case classInt:
+			return valueRule{
+				check: func(rv reflect.Value) error {
+					n := rv.Int()
+					if n < 0 || n > 65535 {
+						n = -1
+					}
+					return withValue(rv, rule.Check(int(n)))
+				},
+				description: validator.Describe(rule),
+			}, nil
+		case classUint:
+			return valueRule{
+				check: func(rv reflect.Value) error {
+					n := rv.Uint()
+					if n > 65535 {
+						return withValue(rv, rule.Check(-1))
+					}
+					return withValue(rv, rule.Check(int(n)))
+				},
+				description: validator.Describe(rule),
+			}, nil
+		}
+		return valueRule{}, unsupported
+
+	case "positive", "negative", "nonzero":
+		if spec.Param != "" {
+			return valueRule{}, errors.New("does not take a parameter")
+		}
+		switch class {
+		case classInt:
+			return onInt(sign[int64](spec.Name)), nil
+		case classUint:
+			return onUint(sign[uint64](spec.Name)), nil
+		case classFloat:
+			return onFloat(sign[float64](spec.Name)), nil
+		}
+		return valueRule{}, unsupported
+
+	case "multipleof":
+		n, err := strconv.ParseInt(spec.Param, 10, 64)
+		if err != nil || n == 0 {
+			return valueRule{}, fmt.Errorf("invalid parameter %q", spec.Param)
+		}
+		switch class {
+		case classInt:
+			return onInt(rules.MultipleOf(n)), nil
+		case classUint:
+			if n < 0 {
+				return valueRule{}, fmt.Errorf("invalid parameter %q", spec.Param)
+			}
+			return onUint(rules.MultipleOf(uint64(n))), nil
+		}
+		return valueRule{}, unsupported
+
+	case "finite", "decimals":
+		if class != classFloat {
+			return valueRule{}, unsupported
+		}
+		if spec.Name == "finite" {
+			return onFloat(rules.Finite[float64]()), nil
+		}
+		n, err := strconv.Atoi(spec.Param)
+		if err != nil || n < 0 {
+			return valueRule{}, fmt.Errorf("invalid parameter %q", spec.Param)
+		}
+		return onFloat(rules.MaxDecimalPlaces[float64](n)), nil
+
+	case "unique":
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return valueRule{}, unsupported
+		}
+		if !t.Elem().Comparable() {
+			return valueRule{}, fmt.Errorf("elements of %s are not comparable", t)
+		}
+		return valueRule{check: unique, description: describe(rules.CodeUnique, nil)}, nil
+	}
+
+	return valueRule{}, errors.New("unknown rule")
+}
+
+// buildBound builds the min, max and len rules, that check the length of
+// strings, the number of elements of collections and the value of numbers
+func buildBound(spec Spec, t reflect.Type, class kindClass) (valueRule, error) {
+	if spec.Param == "" {
+		return valueRule{}, errNoParam
+	}
+
+	switch class {
+	case classString, classCollection:
+		n, err := strconv.Atoi(spec.Param)
+		if err != nil || n < 0 {
+			return valueRule{}, fmt.Errorf("invalid parameter %q", spec.Param)
+		}
+
+		if class == classString {
+			switch spec.Name {
+			case "min":
+				return onString(rules.MinLength(n)), nil
+			case "max":
+				return onString(rules.MaxLength(n)), nil
+			}
+			return onString(rules.Length(n)), nil
+		}
+
+		switch spec.Name {
+		case "min":
+			return onCount(rules.MinCount[any](n)), nil
+		case "max":
+			return onCount(rules.MaxCount[any](n)), nil
+		}
+		min, max := onCount(rules.MinCount[any](n)), onCount(rules.MaxCount[any](n))
+		description := min.description
+		description.Merge(max.description)
+		return valueRule{
+			check: func(rv reflect.Value) error {
+				if err := min.check(rv); err != nil {
+					return err
+				}
+				return max.check(rv)
+			},
+			description: description,
+		}, nil
+	}
+
+	if spec.Name == "len" {
+		return valueRule{}, fmt.Errorf("cannot be applied to %s", t)
+	}
+
+	spec.Name = map[string]string{"min": "gte", "max": "lte"}[spec.Name]
+	return buildComparison(spec, t, class)
+}
+
+// buildComparison builds the rules comparing numbers, and strings for eq and
+// ne, with the value of the parameter
+func buildComparison(spec Spec, t reflect.Type, class kindClass) (valueRule, error) {
+	if spec.Param == "" {
+		return valueRule{}, errNoParam
+	}
+
+	if class == classString {
+		switch spec.Name {
+		case "eq":
+			return onString(rules.Equal(spec.Param)), nil
+		case "ne":
+			return onString(rules.NotEqual(spec.Param)), nil
+		}
+		return valueRule{}, fmt.Errorf("cannot be applied to %s", t)
+	}
+
+	switch class {
+	case classInt:
+		n, err := strconv.ParseInt(spec.Param, 10, 64)
+		if err != nil {
+			return valueRule{}, fmt.Errorf("invalid parameter %q", spec.Param)
+		}
+		return onInt(comparison(spec.Name, n)), nil
+	case classUint:
+		n, err := strconv.ParseUint(spec.Param, 10, 64)
+		if err != nil {
+			return valueRule{}, fmt.Errorf("invalid parameter %q", spec.Param)
+		}
+		return onUint(comparison(spec.Name, n)), nil
+	case classFloat:
+		n, err := strconv.ParseFloat(spec.Param, 64)
+		if err != nil {
+			return valueRule{}, fmt.Errorf("invalid parameter %q", spec.Param)
+		}
+		return onFloat(comparison(spec.Name, n)), nil
+	}
+
+	return valueRule{}, fmt.Errorf("cannot be applied to %s", t)
+}
+
+func comparison[N rules.Ordered](name string, n N) validator.Rule[N] {
+	switch name {
+	case "eq":
+		return rules.Equal(n)
+	case "ne":
+		return rules.NotEqual(n)
+	case "gt":
+		return rules.GreaterThan(n)
+	case "gte":
+		return rules.Min(n)
+	case "lt":
+		return rules.LessThan(n)
+	}
+	return rules.Max(n)
+}
+
+func sign[N rules.Number](name string) validator.Rule[N] {
+	switch name {
+	case "positive":
+		return rules.Positive[N]()
+	case "negative":
+		return rules.Negative[N]()
+	}
+	return rules.NonZero[N]()
+}
+
+// parseNumber parses s as a number of the given class and returns it in the
+// format used by formatNumber
+func parseNumber(s string, t reflect.Type, class kindClass) (string, error) {
+	var formatted string
+	var err error
+
+	switch class {
+	case classInt:
+		var n int64
+		n, err = strconv.ParseInt(s, 10, 64)
+		formatted = strconv.FormatInt(n, 10)
+	case classUint:
+		var n uint64
+		n, err = strconv.ParseUint(s, 10, 64)
+		formatted = strconv.FormatUint(n, 10)
+	case classFloat:
+		var n float64
+		n, err = strconv.ParseFloat(s, 64)
+		formatted = strconv.FormatFloat(n, 'g', -1, 64)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("invalid value %q for %s", s, t)
+	}
+	return formatted, nil
+}
+
+func formatNumber(rv reflect.Value, class kindClass) string {
+	switch class {
+	case classInt:
+		return strconv.FormatInt(rv.Int(), 10)
+	case classUint:
+		return strconv.FormatUint(rv.Uint(), 10)
+	}
+	return strconv.FormatFloat(rv.Float(), 'g', -1, 64)
+}
+
+func onString(rule validator.Rule[string]) valueRule {
+	return valueRule{
+		check: func(rv reflect.Value) error {
+			return withValue(rv, rule.Check(rv.String()))
+		},
+		description: validator.Describe(rule),
+	}
+}
+
+func onInt(rule validator.Rule[int64]) valueRule {
+	return valueRule{
+		check: func(rv reflect.Value) error {
+			return withValue(rv, rule.Check(rv.Int()))
+		},
+		description: validator.Describe(rule),
+	}
+}
+
+func onUint(rule validator.Rule[uint64]) valueRule {
+	return valueRule{
+		check: func(rv reflect.Value) error {
+			return withValue(rv, rule.Check(rv.Uint()))
+		},
+		description: validator.Describe(rule),
+	}
+}
+
+func onFloat(rule validator.Rule[float64]) valueRule {
+	return valueRule{
+		check: func(rv reflect.Value) error {
+			return withValue(rv, rule.Check(rv.Float()))
+		},
+		description: validator.Describe(rule),
+	}
+}
+
+// onCount checks a count rule on the number of elements of the value
+func onCount(rule validator.Rule[[]any]) valueRule {
+	return valueRule{
+		check: func(rv reflect.Value) error {
+			return withValue(rv, rule.Check(make([]any, rv.Len())))
+		},
+		description: validator.Describe(rule),
+	}
+}
+
+// unique fails for every element of a slice or array equal to a previous
+// element, like rules.Unique
+func unique(rv reflect.Value) error {
+	result := validator.Result{}
+	seen := make(map[any]int, rv.Len())
+	// unhashable are the indexes of the elements holding maps or slices, such
+	// as the objects and arrays of a decoded JSON array, compared deeply
+	var unhashable []int
+
+	for i := 0; i < rv.Len(); i++ {
+		item := rv.Index(i).Interface()
+
+		var first int
+		var ok bool
+		if keys.Hashable(rv.Index(i)) {
+			if first, ok = seen[item]; !ok {
+				seen[item] = i
+			}
+		} else if first, ok = deepIndex(rv, unhashable, item); !ok {
+			unhashable = append(unhashable, i)
+		}
+		if !ok {
+			continue
+		}
+
+		result.AddFailure(validator.Failure{
+			Path:    fmt.Sprintf("[%d]", i),
+			Code:    rules.CodeUnique,
+			Message: fmt.Sprintf("must be unique, duplicates element %d", first),
+			Value:   item,
+			Params:  map[string]any{"index": first},
+		})
+	}
+
+	if result.IsSuccess() {
+		return nil
+	}
+	return result
+}
+
+// deepIndex returns the first of the elements of rv at indexes deeply equal
+// to item
+func deepIndex(rv reflect.Value, indexes []int, item any) (int, bool) {
+	for _, i := range indexes {
+		if reflect.DeepEqual(rv.Index(i).Interface(), item) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// describe returns the description of a rule that is not built from a
+// described rule
+func describe(code string, params map[string]any) validator.Description {
+	return validator.Description{Rules: []validator.RuleDescription{{Code: code, Params: params}}}
+}
+
+// numbers returns the values formatted by parseNumber as numbers, to describe
+// them
+func numbers(values []string, class kindClass) []any {
+	parsed := make([]any, len(values))
+	for i, v := range values {
+		switch class {
+		case classInt:
+			parsed[i], _ = strconv.ParseInt(v, 10, 64)
+		case classUint:
+			parsed[i], _ = strconv.ParseUint(v, 10, 64)
+		default:
+			parsed[i], _ = strconv.ParseFloat(v, 64)
+		}
+	}
+	return parsed
+}
+
+// withValue sets the checked value as the value of the failure
+func withValue(rv reflect.Value, err error) error {
+	if err == nil {
+		return nil
+	}
+	return Value(rv.Interface(), err)
+}
@@ -0,0 +1,231 @@
+// Package tags builds validators from struct tags.
+//
+// Constraints are declared in the "validate" tag of the struct fields, as a
+// comma separated list of rules, some of which take a parameter:
+//
+//	type Customer struct {
+//		Name    string   `json:"name" validate:"required,max=50"`
+//		Email   string   `json:"email" validate:"omitempty,email"`
+//		Age     int      `json:"age" validate:"gte=18,lte=130"`
+//		Tags    []string `json:"tags" validate:"max=5,dive,alphanum"`
+//		Address *Address `json:"address" validate:"required"`
+//	}
+//
+// Failures are reported with the json name of the field as path, or the Go
+// name when the field has no json tag. Nested structs and pointers to structs
+// are validated recursively, and the dive rule applies the rules after it to
+// every element of a slice, array or map.
+//
+// A literal comma in a rule parameter must be escaped as "\,", and a field
+// tagged with validate:"-" is not validated.
+package tags
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/cgxarrie-go/validator"
+)
+
+// TagName is the name of the struct tag holding the rules of a field
+const TagName = "validate"
+
+// Spec is a single rule of a tag, with its optional parameter
+type Spec struct {
+	Name  string
+	Param string
+}
+
+// Error reports an invalid tag, found when building a validator
+type Error struct {
+	// Type is the struct type holding the field
+	Type reflect.Type
+	// Field is the Go name of the field, empty for the rules compiled by
+	// CompileRules
+	Field string
+	// Rule is the rule of the tag that is not valid, if known
+	Rule string
+	// Msg describes the problem
+	Msg string
+}
+
+func (e *Error) Error() string {
+	if e.Field == "" {
+		if e.Rule == "" {
+			return fmt.Sprintf("tags: %s: %s", e.Type, e.Msg)
+		}
+		return fmt.Sprintf("tags: %s: rule %q: %s", e.Type, e.Rule, e.Msg)
+	}
+	if e.Rule == "" {
+		return fmt.Sprintf("tags: %s.%s: %s", e.Type, e.Field, e.Msg)
+	}
+	return fmt.Sprintf("tags: %s.%s: rule %q: %s", e.Type, e.Field, e.Rule, e.Msg)
+}
+
+// Parse parses the content of a validate tag into its rules
+func Parse(tag string) ([]Spec, error) {
+	specs := make([]Spec, 0)
+	if strings.TrimSpace(tag) == "" {
+		return specs, nil
+	}
+
+	for _, part := range splitEscaped(tag) {
+		name, param := part, ""
+		hasParam := false
+		if i := strings.IndexByte(part, '='); i >= 0 {
+			name, param, hasParam = part[:i], part[i+1:], true
+		}
+
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("empty rule in tag %q", tag)
+		}
+		if hasParam && param == "" {
+			return nil, fmt.Errorf("rule %q has an empty parameter", name)
+		}
+
+		specs = append(specs, Spec{Name: name, Param: param})
+	}
+
+	return specs, nil
+}
+
+func splitEscaped(tag string) []string {
+	parts := make([]string, 0)
+	current := strings.Builder{}
+
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ',':
+			current.WriteByte(',')
+			i++
+		case tag[i] == ',':
+			parts = append(parts, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(tag[i])
+		}
+	}
+
+	return append(parts, current.String())
+}
+
+var plans sync.Map
+
+// FromTags builds a validator for T from the validate tags of its fields.
+// T must be a struct or a pointer to a struct.
+//
+// The tags of T are compiled once and the compiled plan is cached, so later
+// calls for the same type are cheap. Tag syntax errors, unknown rules and
+// rules that do not apply to the type of their field are reported here, as
+// an *Error, rather than at validation time
+func FromTags[T any]() (validator.Validator[T], error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+
+	plan, err := planFor(t)
+	if err != nil {
+		return nil, err
+	}
+
+	v := validator.New[T]()
+	if len(plan.fields) == 0 {
+		v.AddStep()
+		return v, nil
+	}
+
+	for _, field := range plan.fields {
+		field := field
+		v.AddStep(func(src T) error {
+			rv := reflect.ValueOf(&src).Elem()
+			for rv.Kind() == reflect.Pointer {
+				if rv.IsNil() {
+					return nil
+				}
+				rv = rv.Elem()
+			}
+
+			res := field.validate(rv)
+			if res.IsSuccess() {
+				return nil
+			}
+			return res
+		}).WithDescription(field.describe(map[*structPlan]bool{plan: true}))
+	}
+
+	return v, nil
+}
+
+// MustFromTags is like FromTags but panics if the tags of T are not valid
+func MustFromTags[T any]() validator.Validator[T] {
+	v, err := FromTags[T]()
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Rules checks values with a list of rules, as written in a validate tag
+type Rules struct {
+	plan *valuePlan
+}
+
+// CompileRules compiles specs into the rules checking values of type t. The
+// tags of t, when it is a struct, are not checked by the rules
+func CompileRules(t reflect.Type, specs []Spec) (*Rules, error) {
+	c := newCompiler()
+	c.noNested = true
+
+	plan, err := c.compileValue(t, reflect.StructField{}, t, specs)
+	if err != nil {
+		return nil, err
+	}
+	return &Rules{plan: plan}, nil
+}
+
+// Validate checks value with the rules. A nil value only fails when it is
+// required
+func (r *Rules) Validate(value any) validator.Result {
+	if r.plan == nil {
+		return validator.Result{}
+	}
+	return r.plan.validate(reflect.ValueOf(value))
+}
+
+// Required reports whether the rules include the required rule
+func (r *Rules) Required() bool {
+	return r.plan != nil && r.plan.required
+}
+
+// Describe describes the constraints checked by the rules, except required,
+// that is reported by Required
+func (r *Rules) Describe() validator.Description {
+	if r.plan == nil {
+		return validator.Description{}
+	}
+	return r.plan.describe(make(map[*structPlan]bool))
+}
+
+func planFor(t reflect.Type) (*structPlan, error) {
+	root := t
+	for root.Kind() == reflect.Pointer {
+		root = root.Elem()
+	}
+
+	if root.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("tags: %s is not a struct", t)
+	}
+
+	if plan, ok := plans.Load(root); ok {
+		return plan.(*structPlan), nil
+	}
+
+	plan, err := newCompiler().compileStruct(root)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, _ := plans.LoadOrStore(root, plan)
+	return actual.(*structPlan), nil
+}
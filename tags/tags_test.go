@@ -0,0 +1,266 @@
+package tags_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/cgxarrie-go/validator/rules"
+	"github.com/cgxarrie-go/validator/tags"
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	City    string `json:"city" validate:"required,max=20"`
+	Country string `json:"country" validate:"required,len=2,uppercase"`
+}
+
+type lineItem struct {
+	SKU      string `json:"sku" validate:"required,pattern=^[A-Z]{3}-\\d+$"`
+	Quantity int    `json:"quantity" validate:"gt=0,lte=100"`
+}
+
+type customer struct {
+	Name     string            `json:"name" validate:"required,max=10"`
+	Email    string            `json:"email" validate:"omitempty,email"`
+	Age      int               `json:"age" validate:"gte=18"`
+	Score    float64           `validate:"min=0,max=1,decimals=2"`
+	Status   string            `json:"status" validate:"oneof=active blocked"`
+	Level    uint8             `json:"level" validate:"oneof=1 2 3"`
+	Tags     []string          `json:"tags" validate:"max=3,unique,dive,alphanum"`
+	Labels   map[string]string `json:"labels" validate:"dive,required"`
+	Home     address           `json:"home"`
+	Billing  *address          `json:"billing" validate:"required"`
+	Shipping *address          `json:"shipping"`
+	Lines    []lineItem        `json:"lines" validate:"min=1,dive"`
+	Internal string            `validate:"-"`
+	ignored  string
+}
+
+func validCustomer() customer {
+	return customer{
+		Name:    "John",
+		Age:     30,
+		Score:   0.5,
+		Status:  "active",
+		Level:   2,
+		Tags:    []string{"vip"},
+		Home:    address{City: "Barcelona", Country: "ES"},
+		Billing: &address{City: "Madrid", Country: "ES"},
+		Lines:   []lineItem{{SKU: "ABC-1", Quantity: 1}},
+	}
+}
+
+func TestFromTags_WhenValueIsValid_ShouldReturnSuccess(t *testing.T) {
+	v, err := tags.FromTags[customer]()
+
+	assert.NoError(t, err)
+	result := v.Validate(validCustomer())
+	assert.True(t, result.IsSuccess(), result.Error())
+}
+
+func TestFromTags_WhenFieldsAreInvalid_ShouldReportFailuresWithPaths(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *customer)
+		path   string
+		code   string
+	}{
+		{name: "required", modify: func(c *customer) { c.Name = "" }, path: "name", code: validator.CodeRequired},
+		{name: "max length", modify: func(c *customer) { c.Name = "John Smith Jr" }, path: "name", code: rules.CodeMaxLength},
+		{name: "omitempty with value", modify: func(c *customer) { c.Email = "nope" }, path: "email", code: rules.CodeEmail},
+		{name: "gte", modify: func(c *customer) { c.Age = 17 }, path: "age", code: rules.CodeMin},
+		{name: "float max", modify: func(c *customer) { c.Score = 1.5 }, path: "Score", code: rules.CodeMax},
+		{name: "decimals", modify: func(c *customer) { c.Score = 0.125 }, path: "Score", code: rules.CodeDecimalPlaces},
+		{name: "oneof string", modify: func(c *customer) { c.Status = "deleted" }, path: "status", code: rules.CodeOneOf},
+		{name: "oneof number", modify: func(c *customer) { c.Level = 4 }, path: "level", code: rules.CodeOneOf},
+		{name: "collection max", modify: func(c *customer) { c.Tags = []string{"a", "b", "c", "d"} }, path: "tags", code: rules.CodeMaxCount},
+		{name: "unique", modify: func(c *customer) { c.Tags = []string{"a", "a"} }, path: "tags[1]", code: rules.CodeUnique},
+		{name: "dive slice", modify: func(c *customer) { c.Tags = []string{"a", "b-c"} }, path: "tags[1]", code: rules.CodeAlphanumeric},
+		{name: "dive map", modify: func(c *customer) { c.Labels = map[string]string{"env": ""} }, path: "labels[env]", code: validator.CodeRequired},
+		{name: "nested struct", modify: func(c *customer) { c.Home.Country = "es" }, path: "home.country", code: rules.CodeUppercase},
+		{name: "required pointer", modify: func(c *customer) { c.Billing = nil }, path: "billing", code: validator.CodeRequired},
+		{name: "nested pointer", modify: func(c *customer) { c.Shipping = &address{City: "Bilbao"} }, path: "shipping.country", code: validator.CodeRequired},
+		{name: "dive structs", modify: func(c *customer) { c.Lines = append(c.Lines, lineItem{SKU: "abc", Quantity: 1}) }, path: "lines[1].sku", code: rules.CodePattern},
+		{name: "collection min", modify: func(c *customer) { c.Lines = nil }, path: "lines", code: rules.CodeMinCount},
+	}
+
+	v := tags.MustFromTags[customer]()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validCustomer()
+			tt.modify(&c)
+
+			result := v.Validate(c)
+
+			failures := result.Failures()
+			if assert.Len(t, failures, 1, result.Error()) {
+				assert.Equal(t, tt.path, failures[0].Path)
+				assert.Equal(t, tt.code, failures[0].Code)
+			}
+		})
+	}
+}
+
+func TestFromTags_WhenTypeIsAPointer_ShouldValidateTheStruct(t *testing.T) {
+	v, err := tags.FromTags[*address]()
+
+	assert.NoError(t, err)
+	assert.True(t, v.Validate(&address{City: "Barcelona", Country: "ES"}).IsSuccess())
+	assert.Equal(t, []string{validator.CodeRequired}, v.Validate(&address{City: "Barcelona"}).Codes())
+}
+
+func TestFromTags_WhenStructHasNoRules_ShouldReturnSuccess(t *testing.T) {
+	type plain struct {
+		Name string
+	}
+
+	v, err := tags.FromTags[plain]()
+
+	assert.NoError(t, err)
+	assert.True(t, v.Validate(plain{}).IsSuccess())
+}
+
+type node struct {
+	Name     string  `json:"name" validate:"required"`
+	Children []*node `json:"children" validate:"dive"`
+}
+
+func TestFromTags_WhenTypeIsRecursive_ShouldValidateAllLevels(t *testing.T) {
+	v, err := tags.FromTags[node]()
+
+	assert.NoError(t, err)
+	result := v.Validate(node{Name: "root", Children: []*node{{Name: "a", Children: []*node{{}}}}})
+	assert.Len(t, result.FailuresFor("children[0].children[0].name"), 1)
+}
+
+func TestFromTags_WhenTagsAreInvalid_ShouldReturnError(t *testing.T) {
+	type unknownRule struct {
+		Name string `validate:"required,shiny"`
+	}
+	type invalidParam struct {
+		Name string `validate:"max=ten"`
+	}
+	type missingParam struct {
+		Name string `validate:"max"`
+	}
+	type wrongKind struct {
+		Age int `validate:"email"`
+	}
+	type invalidPattern struct {
+		Name string `validate:"pattern=[a-"`
+	}
+	type diveOnString struct {
+		Name string `validate:"dive,required"`
+	}
+	type emptyRule struct {
+		Name string `validate:"required,,max=3"`
+	}
+
+	tests := []struct {
+		name string
+		fn   func() error
+		rule string
+	}{
+		{name: "unknown rule", fn: func() error { _, err := tags.FromTags[unknownRule](); return err }, rule: "shiny"},
+		{name: "invalid param", fn: func() error { _, err := tags.FromTags[invalidParam](); return err }, rule: "max"},
+		{name: "missing param", fn: func() error { _, err := tags.FromTags[missingParam](); return err }, rule: "max"},
+		{name: "wrong kind", fn: func() error { _, err := tags.FromTags[wrongKind](); return err }, rule: "email"},
+		{name: "invalid pattern", fn: func() error { _, err := tags.FromTags[invalidPattern](); return err }, rule: "pattern"},
+		{name: "dive on string", fn: func() error { _, err := tags.FromTags[diveOnString](); return err }, rule: "dive"},
+		{name: "empty rule", fn: func() error { _, err := tags.FromTags[emptyRule](); return err }, rule: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.fn()
+
+			var tagErr *tags.Error
+			if assert.True(t, errors.As(err, &tagErr), "error %v", err) {
+				assert.Equal(t, tt.rule, tagErr.Rule)
+			}
+		})
+	}
+}
+
+func TestFromTags_WhenUniqueElementsAreInterfaces_ShouldCompareMapsAndSlicesDeeply(t *testing.T) {
+	// Arrange
+	type payload struct {
+		Items []any `json:"items" validate:"unique"`
+	}
+	v := tags.MustFromTags[payload]()
+
+	// Act
+	valid := v.Validate(payload{Items: []any{map[string]any{"a": 1}, map[string]any{"a": 2}, []any{1}, 1}})
+	invalid := v.Validate(payload{Items: []any{map[string]any{"a": 1}, []any{1}, 1, map[string]any{"a": 1}, []any{1}}})
+
+	// Assert
+	assert.True(t, valid.IsSuccess(), valid.Error())
+	failures := invalid.Failures()
+	if assert.Len(t, failures, 2) {
+		assert.Equal(t, "items[3]", failures[0].Path)
+		assert.Equal(t, rules.CodeUnique, failures[0].Code)
+		assert.Equal(t, "items[4]", failures[1].Path)
+		assert.Equal(t, map[string]any{"index": 1}, failures[1].Params)
+	}
+}
+
+func TestFromTags_WhenMapKeysAreNumbers_ShouldCheckThemInNumericOrder(t *testing.T) {
+	// Arrange
+	type inventory struct {
+		Stock map[int]int `json:"stock" validate:"dive,min=0"`
+	}
+	v := tags.MustFromTags[inventory]()
+
+	// Act
+	result := v.Validate(inventory{Stock: map[int]int{10: -1, 2: -1, 1: -1, 3: 4}})
+
+	// Assert
+	paths := make([]string, 0)
+	for _, f := range result.Failures() {
+		paths = append(paths, f.Path)
+	}
+	assert.Equal(t, []string{"stock[1]", "stock[2]", "stock[10]"}, paths)
+}
+
+func TestFromTags_WhenTypeIsNotAStruct_ShouldReturnError(t *testing.T) {
+	_, err := tags.FromTags[string]()
+
+	assert.Error(t, err)
+	assert.Panics(t, func() { tags.MustFromTags[[]int]() })
+}
+
+func TestParse(t *testing.T) {
+	specs, err := tags.Parse(`required,max=50,pattern=^a\,b$`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []tags.Spec{
+		{Name: "required"},
+		{Name: "max", Param: "50"},
+		{Name: "pattern", Param: "^a,b$"},
+	}, specs)
+}
+
+func TestCompileRules_WhenValueIsChecked_ShouldApplyRulesOnly(t *testing.T) {
+	specs := []tags.Spec{{Name: "required"}, {Name: "max", Param: "3"}, {Name: "dive"}, {Name: "alpha"}}
+	rules, err := tags.CompileRules(reflect.TypeOf([]string{}), specs)
+
+	assert.NoError(t, err)
+	assert.True(t, rules.Validate([]string{"a", "b"}).IsSuccess())
+	assert.Equal(t, []string{validator.CodeRequired}, rules.Validate(nil).Codes())
+	assert.Equal(t, "[1]", rules.Validate([]string{"a", "b1"}).Failures()[0].Path)
+
+	// the tags of nested structs are not checked
+	structRules, err := tags.CompileRules(reflect.TypeOf(address{}), []tags.Spec{{Name: "required"}})
+	assert.NoError(t, err)
+	assert.True(t, structRules.Validate(address{City: "x"}).IsSuccess())
+}
+
+func TestCompileRules_WhenRuleDoesNotApply_ShouldReturnError(t *testing.T) {
+	_, err := tags.CompileRules(reflect.TypeOf(0), []tags.Spec{{Name: "email"}})
+
+	assert.EqualError(t, err, `tags: int: rule "email": cannot be applied to int`)
+	assert.True(t, tags.KnownRule("email"))
+	assert.False(t, tags.KnownRule("nope"))
+}
@@ -0,0 +1,24 @@
+package validator
+
+// Translator renders the messages of failures in a locale, e.g. from the
+// message catalogs of the i18n subpackage
+type Translator interface {
+	// Translate returns the message of f in the locale, such as "es-MX",
+	// and false when it has no message for the key of f
+	Translate(locale string, f Failure) (string, bool)
+}
+
+// Translate returns a copy of the result whose failure messages, including
+// warnings and infos, are rendered by t in the given locale. Failures t has
+// no message for keep their message
+func (e Result) Translate(t Translator, locale string) Result {
+	translated := Result{skipped: e.skipped, contextErr: e.contextErr}
+
+	for _, f := range e.all() {
+		if msg, ok := t.Translate(locale, f); ok {
+			f.Message = msg
+		}
+		translated.add(f)
+	}
+	return translated
+}
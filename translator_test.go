@@ -0,0 +1,63 @@
+package validator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+// upperTranslator translates the failures with code "not_empty" to locale "xx"
+type upperTranslator struct{}
+
+func (upperTranslator) Translate(locale string, f validator.Failure) (string, bool) {
+	if locale != "xx" || f.MessageKey() != validator.CodeNotEmpty {
+		return "", false
+	}
+	return "MUST NOT BE EMPTY", true
+}
+
+func Test_Translate_WhenTranslatorHasMessages_ShouldReplaceThemInACopy(t *testing.T) {
+	// Arrange
+	result := failedResult()
+
+	// Act
+	translated := result.Translate(upperTranslator{}, "xx")
+
+	// Assert
+	assert.Equal(t, []string{
+		"name: MUST NOT BE EMPTY",
+		"lines[0].quantity: must be greater than or equal to 1",
+	}, translated.GetFailureMessages())
+	assert.Equal(t, "name: must not be empty", result.GetFailureMessages()[0])
+}
+
+func Test_Translate_WhenValidationIsInterrupted_ShouldKeepTheContextError(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	v := validator.New[string]()
+	v.AddStep(func(string) error { return nil })
+
+	// Act
+	translated := v.ValidateContext(ctx, "").Translate(upperTranslator{}, "xx")
+
+	// Assert
+	assert.ErrorIs(t, translated.ContextErr(), context.Canceled)
+}
+
+func Test_MessageKey_WhenKeyIsEmpty_ShouldReturnTheCode(t *testing.T) {
+	// Arrange
+	coded := validator.Failure{Code: validator.CodeNotEmpty}
+	keyed := validator.Failure{Code: validator.CodePredicate, Key: "order.closed"}
+
+	// Act
+	codedKey := coded.MessageKey()
+	keyedKey := keyed.MessageKey()
+
+	// Assert
+	assert.Equal(t, validator.CodeNotEmpty, codedKey)
+	assert.Equal(t, "order.closed", keyedKey)
+}
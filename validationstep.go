@@ -1,13 +1,173 @@
 package validator
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
 type validationStep[T any] struct {
+	owner          *validator[T]
+	name           string
+	dependsOn      []string
 	breakOnFailure bool
-	validator      func(T) error
+	validator      func(context.Context, T) error
 	err            error
 	defaultErr     error
+	describe       func() Description
+	severity       Severity
+	conditions     []func(T) bool
 }
 
 func (v *validationStep[T]) BreakOnFailure() *validationStep[T] {
 	v.breakOnFailure = true
 	return v
 }
+
+// WithError replaces the failure returned by the step with err. The original
+// failure is kept wrapped, so it can still be found with errors.Is and
+// errors.As
+func (v *validationStep[T]) WithError(err error) *validationStep[T] {
+	v.err = err
+	return v
+}
+
+// WithMessage replaces the failure returned by the step with a failure with
+// the message formatted according to format and args
+func (v *validationStep[T]) WithMessage(format string, args ...any) *validationStep[T] {
+	return v.WithError(fmt.Errorf(format, args...))
+}
+
+// WithDefaultError sets the error used instead of the failure returned by the
+// step when its message is empty. It takes precedence over the default error
+// of the validator
+func (v *validationStep[T]) WithDefaultError(err error) *validationStep[T] {
+	v.defaultErr = err
+	return v
+}
+
+// WithSeverity reports the errors of the step with the given severity, e.g.
+// as warnings that do not make the validation fail. Failures already reported
+// as warnings or infos keep their severity
+func (v *validationStep[T]) WithSeverity(severity Severity) *validationStep[T] {
+	v.severity = severity
+	return v
+}
+
+// When makes the step run only when predicate returns true for the validated
+// value, e.g. to check the VAT number only for EU countries. When called
+// several times, all the predicates must hold
+func (v *validationStep[T]) When(predicate func(T) bool) *validationStep[T] {
+	v.conditions = append(v.conditions, predicate)
+	return v
+}
+
+// Unless makes the step run only when predicate returns false for the
+// validated value
+func (v *validationStep[T]) Unless(predicate func(T) bool) *validationStep[T] {
+	return v.When(func(src T) bool { return !predicate(src) })
+}
+
+// run runs the step on src when its conditions hold
+func (v *validationStep[T]) run(ctx context.Context, src T) error {
+	for _, condition := range v.conditions {
+		if !condition(src) {
+			return nil
+		}
+	}
+
+	return v.validator(ctx, src)
+}
+
+// WithDescription describes the constraints checked by the step, which are
+// otherwise opaque to Describe
+func (v *validationStep[T]) WithDescription(d Description) *validationStep[T] {
+	v.describe = func() Description { return d }
+	return v
+}
+
+// stepError is the error of a step whose failure was replaced by a custom
+// error. It matches the custom error and unwraps to the original failure
+type stepError struct {
+	err   error
+	cause error
+}
+
+func (e stepError) Error() string {
+	return e.err.Error()
+}
+
+func (e stepError) Unwrap() error {
+	return e.cause
+}
+
+func (e stepError) Is(target error) bool {
+	return errors.Is(e.err, target)
+}
+
+func (e stepError) As(target any) bool {
+	return errors.As(e.err, target)
+}
+
+// resolveError returns the error to be reported for a step that failed with
+// err, replacing it by the step custom error or, when err is not descriptive,
+// by the default error
+func (v *validationStep[T]) resolveError(err error, defaultErr error) error {
+	original := Result{}
+	original.AddFailure(err)
+	if original.IsSuccess() {
+		// only warnings and infos, which are not replaced
+		return err
+	}
+
+	custom := v.err
+	if custom == nil && strings.TrimSpace(err.Error()) == "" {
+		custom = returnError(v.defaultErr, defaultErr)
+	}
+
+	if custom == nil {
+		return err
+	}
+
+	failure := Failure{Message: custom.Error()}
+	if f, ok := custom.(Failure); ok {
+		failure = f
+	}
+
+	if original.contextErr != nil {
+		return err
+	}
+
+	if failure.Path == "" && len(original.failures) > 0 {
+		failure.Path = original.failures[0].Path
+		for _, f := range original.failures[1:] {
+			if f.Path != failure.Path {
+				failure.Path = ""
+				break
+			}
+		}
+	}
+	if failure.Value == nil && len(original.failures) == 1 {
+		failure.Value = original.failures[0].Value
+	}
+
+	failure.Err = stepError{err: custom, cause: err}
+	if len(original.warnings) == 0 && len(original.infos) == 0 {
+		return failure
+	}
+
+	// the warnings and infos of the step are not replaced
+	resolved := Result{warnings: original.warnings, infos: original.infos}
+	resolved.add(failure)
+	return resolved
+}
+
+// returnError returns the custom error if it is not nil, otherwise returns
+// the default error
+func returnError(customError, defaultError error) error {
+	if customError != nil {
+		return customError
+	}
+	return defaultError
+}
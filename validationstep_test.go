@@ -0,0 +1,108 @@
+package validator_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cgxarrie-go/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Step_WhenWithErrorIsSet_ShouldReplaceFailureAndKeepOriginal(t *testing.T) {
+	// Arrange
+	errInvalidCustomer := errors.New("invalid customer")
+	errOriginal := errors.New("original")
+	req := dummyType{}
+
+	v := validator.New[dummyType]()
+	v.AddStep(func(dummyType) error { return errOriginal }).
+		WithError(errInvalidCustomer)
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	failures := result.GetFailures()
+	assert.Len(t, failures, 1)
+	assert.EqualError(t, failures[0], "invalid customer")
+	assert.True(t, errors.Is(failures[0], errInvalidCustomer))
+	assert.True(t, errors.Is(failures[0], errOriginal))
+}
+
+func Test_Step_WhenWithMessageIsSet_ShouldReplaceFailureMessageAndKeepPath(t *testing.T) {
+	// Arrange
+	req := customer{}
+
+	v := validator.New[customer]()
+	v.AddStep(func(customer) error {
+		result := validator.Result{}
+		result.AddFailure(validator.Failure{Path: "age", Code: "min", Message: "too low", Value: 3})
+		return result
+	}).WithMessage("age must be at least %d", 18)
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	failures := result.FailuresFor("age")
+	assert.Len(t, failures, 1)
+	assert.Equal(t, "age must be at least 18", failures[0].Message)
+	assert.Equal(t, 3, failures[0].Value)
+	var original validator.Result
+	assert.True(t, errors.As(failures[0].Err, &original))
+	assert.Equal(t, "too low", original.Failures()[0].Message)
+}
+
+func Test_Step_WhenWithErrorIsAFailure_ShouldUseIt(t *testing.T) {
+	// Arrange
+	req := dummyType{}
+
+	v := validator.New[dummyType]()
+	v.AddStep(func(dummyType) error { return errors.New("original") }).
+		WithError(validator.Failure{Path: "field", Code: "custom", Message: "custom message"})
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	failures := result.Failures()
+	assert.Len(t, failures, 1)
+	assert.Equal(t, "field", failures[0].Path)
+	assert.Equal(t, "custom", failures[0].Code)
+	assert.Equal(t, "custom message", failures[0].Message)
+}
+
+func Test_Step_WhenStepFailsWithEmptyMessage_ShouldUseDefaultError(t *testing.T) {
+	// Arrange
+	errDefault := errors.New("invalid request")
+	errStepDefault := errors.New("step 2 failed")
+	req := dummyType{}
+
+	v := validator.New[dummyType]().WithDefaultError(errDefault)
+	v.AddStep(func(dummyType) error { return errors.New("") })
+	v.AddStep(func(dummyType) error { return errors.New(" ") }).
+		WithDefaultError(errStepDefault)
+	v.AddStep(func(dummyType) error { return errors.New("Error-3") })
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	assert.Equal(t, []string{"invalid request", "step 2 failed", "Error-3"}, result.GetFailureMessages())
+	assert.True(t, errors.Is(result.GetFailures()[0], errDefault))
+}
+
+func Test_Step_WhenStepPasses_ShouldNotReportCustomError(t *testing.T) {
+	// Arrange
+	req := dummyType{}
+
+	v := validator.New[dummyType]()
+	v.AddStep(func(dummyType) error { return nil }).
+		WithMessage("should not be reported")
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	assert.True(t, result.IsSuccess())
+}
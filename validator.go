@@ -13,6 +13,12 @@
 // Types:
 //   - validator[T any]: A generic validator type that holds validation steps
 //     and a flag indicating whether to break on failure.
+//   - Result: The outcome of a validation, holding the structured failures.
+//   - Failure: A single validation failure with its field path, code,
+//     message, offending value and rule parameters.
+//   - Problem: The RFC 9457 problem details of a Result, returned by
+//     Result.Problem and used to marshal a Result to JSON.
+//   - Translator: Renders the failure messages of a Result in a locale.
 //
 // Functions:
 //   - (v validator[T]) Validate(src T) Result: Validates the given data instance
@@ -24,55 +30,156 @@
 //     flag to true and returns the validator instance.
 //   - (v *validator[T]) AddStep() *validationStep[T]: Adds a new validation step
 //     to the validator and returns a pointer to the newly added validation step.
+//   - RuleFor[T, F any](v *validator[T], name string, get func(T) F): Adds a
+//     step checking the field returned by get against a chain of rules.
+//   - ForEach, ForEachValue, ForEachKey: Add a step checking every element of
+//     a slice, or every value or key of a map.
+//   - SetValidator: Adds a step running a child validator on a nested value.
+//   - AllOf, AnyOf, OneOf, Not, And, Or: Build a validator combining the
+//     results of other validators.
+//   - (v validator[T]) ValidateContext(ctx context.Context, src T) Result:
+//     Validates the given data instance passing ctx to every step. When ctx
+//     is done, the remaining steps are not run and the context error is
+//     reported by Result.ContextErr.
+//   - (v *validator[T]) AddStepContext() *validationStep[T]: Adds context
+//     aware validation steps to the validator.
+//   - (v *validator[T]) Parallel(workers int) *validator[T]: Runs the steps
+//     concurrently in a pool of workers, keeping failures in step order.
+//   - (v *validator[T]) WithDefaultError(err error) *validator[T]: Sets the
+//     error reported when a step fails with an empty message.
+//   - (v *validator[T]) WhenAll / WhenAny(predicates ...func(T) bool): Gate
+//     the steps added in a block, which run only when all, or any, of the
+//     predicates hold. Single steps are gated with When and Unless.
+//   - (v *validationStep[T]) Named / DependsOn: Name a step and make other
+//     steps run only when it passed. Skipped steps are reported by
+//     Result.Skipped.
+//   - (v validator[T]) Check() error: Reports the steps depending on steps
+//     that are not defined. MustCheck panics instead.
+//   - (v *validator[T]) Strict() *validator[T]: Promotes the warnings of the
+//     steps to errors. Failures have a Severity, and only errors make a
+//     validation fail, see Warning, Info and WithSeverity.
+//   - (v validator[T]) Describe() Description: Describes the constraints of
+//     the steps, e.g. to export them as a JSON Schema. Custom steps are opaque.
+//   - SetProblemTypeBase(base string): Sets the default base URI of the
+//     problem types. Result.ProblemWithBase uses a base of its own.
+//   - ProblemType(base, name string) string: Returns the type URI of a
+//     problem, joined to the base URI.
+//   - (e Result) Translate(t Translator, locale string) Result: Renders the
+//     failure messages in a locale, e.g. with the catalogs of the i18n package.
 //   - returnError(customError, defaultError error) error: Returns the custom error
 //     if it is not nil, otherwise returns the default error.
 package validator
 
+import (
+	"context"
+	"errors"
+)
+
 type Validator[T any] interface {
 	Validate(src T) Result
 }
 
+// ContextValidator is a Validator able to receive a context, carrying
+// deadlines, cancellation and request-scoped values to its steps
+type ContextValidator[T any] interface {
+	Validator[T]
+	ValidateContext(ctx context.Context, src T) Result
+}
+
 type validator[T any] struct {
 	breakOnFailure bool
-	validators     []validationStep[T]
+	strict         bool
+	workers        int
+	defaultErr     error
+	validators     []*validationStep[T]
+	steps          map[string]*validationStep[T]
 }
 
 func (v validator[T]) Validate(src T) Result {
+	return v.ValidateContext(context.Background(), src)
+}
+
+func (v validator[T]) ValidateContext(ctx context.Context, src T) Result {
 	result := Result{}
 
 	if len(v.validators) == 0 {
-		result.AddFailureMessage("No validation steps defined")
+		result.AddFailure(Failure{
+			Code:    CodeNoSteps,
+			Message: "No validation steps defined",
+		})
 		return result
 	}
 
-	for _, step := range v.validators {
-		err := step.validator(src)
-
-		if err != nil {
+	if v.workers > 1 {
+		return v.validateParallel(ctx, src)
+	}
 
-			if res, ok := err.(Result); ok {
-				for _, v := range res.GetFailures() {
-					result.AddFailure(v)
-				}
-			} else {
-				result.AddFailure(err)
-			}
+	failed := make(map[*validationStep[T]]bool)
+	for _, step := range v.order() {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			result.contextErr = ctxErr
+			return result
+		}
 
-			if step.breakOnFailure || v.breakOnFailure {
+		if blocked, ok := v.blocked(step, failed); ok {
+			result.Merge(blocked)
+			failed[step] = true
+			if blocked.IsFailure() && (step.breakOnFailure || v.breakOnFailure) {
 				return result
 			}
+			continue
+		}
+
+		err := step.run(ctx, src)
+
+		before := len(result.failures)
+		stop := v.addStepError(ctx, &result, step, err)
+		failed[step] = len(result.failures) > before
+		if stop {
+			return result
 		}
 	}
 
 	return result
 }
 
+// addStepError adds the error returned by step to result and reports whether
+// the validation must stop. An error caused by ctx being done is reported as
+// the result context error instead of as a failure
+func (v validator[T]) addStepError(ctx context.Context, result *Result, step *validationStep[T], err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil && errors.Is(err, ctxErr) {
+		result.contextErr = ctxErr
+		return true
+	}
+
+	failures := Result{}
+	failures.AddFailure(step.resolveError(err, v.defaultErr))
+	failures = failures.withSeverity(step.severity)
+	if v.strict {
+		failures = failures.Strict()
+	}
+	result.Merge(failures)
+
+	return result.contextErr != nil || ((step.breakOnFailure || v.breakOnFailure) && failures.IsFailure())
+}
+
+// fails reports whether result makes the validation fail, which in strict
+// mode includes its warnings
+func (v *validator[T]) fails(result Result) bool {
+	return result.IsFailure() || (v.strict && result.HasWarnings())
+}
+
 // New creates a new validator instance with no validation steps and the
 // break-on-failure flag set to false.
 func New[T any]() *validator[T] {
 	return &validator[T]{
 		breakOnFailure: false,
-		validators:     make([]validationStep[T], 0),
+		validators:     make([]*validationStep[T], 0),
+		steps:          make(map[string]*validationStep[T]),
 	}
 
 }
@@ -82,14 +189,51 @@ func (v *validator[T]) BreakOnFailure() *validator[T] {
 	return v
 }
 
+// Strict promotes the warnings reported by the steps to errors, so the
+// validation fails on them. Infos are still reported as infos
+func (v *validator[T]) Strict() *validator[T] {
+	v.strict = true
+	return v
+}
+
+// WithDefaultError sets the error used instead of the failure returned by a
+// step when its message is empty, unless the step has its own default error
+func (v *validator[T]) WithDefaultError(err error) *validator[T] {
+	v.defaultErr = err
+	return v
+}
+
 func (v *validator[T]) AddStep(steps ...func(req T) error) *validationStep[T] {
 
 	if steps == nil {
-		steps = []func(req T) error{func(T) error { return nil }}
+		return v.AddStepContext()
+	}
+
+	ctxSteps := make([]func(ctx context.Context, req T) error, len(steps))
+	for i, step := range steps {
+		step := step
+		ctxSteps[i] = func(_ context.Context, req T) error {
+			return step(req)
+		}
+	}
+
+	return v.AddStepContext(ctxSteps...)
+}
+
+// AddStepContext adds validation steps receiving the context passed to
+// ValidateContext, and returns the last added step
+func (v *validator[T]) AddStepContext(steps ...func(ctx context.Context, req T) error) *validationStep[T] {
+
+	noop := steps == nil
+	if noop {
+		steps = []func(ctx context.Context, req T) error{
+			func(context.Context, T) error { return nil },
+		}
 	}
 
 	for _, step := range steps {
-		validationStep := validationStep[T]{
+		validationStep := &validationStep[T]{
+			owner:          v,
 			breakOnFailure: false,
 			validator:      step,
 		}
@@ -98,18 +242,35 @@ func (v *validator[T]) AddStep(steps ...func(req T) error) *validationStep[T] {
 
 	}
 
-	return &v.validators[len(v.validators)-1]
+	last := v.validators[len(v.validators)-1]
+	if noop {
+		// the no-op step checks nothing, so it is not opaque
+		last.WithDescription(Description{})
+	}
+	return last
 }
 
 func (v *validator[T]) AddValidator(validator Validator[T]) {
 
-	step := func(req T) error {
-		result := validator.Validate(req)
-		if result.IsFailure() {
+	step := func(ctx context.Context, req T) error {
+		result := validateContext(ctx, validator, req)
+		if !result.isEmpty() {
 			return result
 		}
 		return nil
 	}
 
-	v.AddStep(step)
+	v.AddStepContext(step).describe = func() Description {
+		return Describe(validator)
+	}
+}
+
+// validateContext runs validator passing ctx when the validator is a
+// ContextValidator
+func validateContext[T any](ctx context.Context, validator Validator[T], src T) Result {
+	if v, ok := validator.(ContextValidator[T]); ok {
+		return v.ValidateContext(ctx, src)
+	}
+
+	return validator.Validate(src)
 }
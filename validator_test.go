@@ -47,6 +47,37 @@ func Test_Validator_WhenNoStepsDefined_ShouldReturnFailure(t *testing.T) {
 	errors := result.GetFailures()
 	assert.Len(t, errors, 1)
 	assert.ErrorContains(t, errors[0], "No validation steps defined")
+	assert.Equal(t, []string{validator.CodeNoSteps}, result.Codes())
+}
+
+func Test_Validator_WhenStepReturnsResult_ShouldFlattenFailures(t *testing.T) {
+
+	// Arrange
+	req := dummyType{}
+
+	step1 := func(src dummyType) error {
+		result := validator.Result{}
+		result.AddFailure(validator.Failure{Path: "field1", Code: "required", Message: "is required"})
+		result.AddFailure(validator.Failure{Path: "field2", Code: "max_length", Message: "is too long"})
+		return result
+	}
+	step2 := func(src dummyType) error { return errors.New("Error-2") }
+
+	v := validator.New[dummyType]()
+	v.AddStep(step1)
+	v.AddStep(step2)
+
+	// Act
+	result := v.Validate(req)
+
+	// Assert
+	assert.True(t, result.IsFailure())
+	failures := result.Failures()
+	assert.Len(t, failures, 3)
+	assert.Equal(t, "field1", failures[0].Path)
+	assert.Equal(t, "field2", failures[1].Path)
+	assert.Equal(t, "Error-2", failures[2].Message)
+	assert.Equal(t, []string{"required", "max_length"}, result.Codes())
 }
 
 func Test_Validator_WhenBreakOnFailureIsSet_ShouldReturnFailureOnFirstError(t *testing.T) {